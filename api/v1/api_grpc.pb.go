@@ -0,0 +1,227 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: api/v1/api.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExtensionServiceClient is the client API for ExtensionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtensionServiceClient interface {
+	// SobeyPodSandboxStats returns the stats of the pod sandbox. The Sobey
+	// prefix keeps the method apart from the CRI RuntimeService methods served
+	// by the same receiver.
+	SobeyPodSandboxStats(ctx context.Context, in *SobeyPodSandboxStatsRequest, opts ...grpc.CallOption) (*SobeyPodSandboxStatsResponse, error)
+	// ListSobeyPodSandboxStats returns the stats of the pod sandboxes matching a filter.
+	ListSobeyPodSandboxStats(ctx context.Context, in *ListSobeyPodSandboxStatsRequest, opts ...grpc.CallOption) (*ListSobeyPodSandboxStatsResponse, error)
+	// GarbageCollectImages removes the unused images to bring the usage of
+	// the image filesystem down to the low threshold.
+	GarbageCollectImages(ctx context.Context, in *GarbageCollectImagesRequest, opts ...grpc.CallOption) (*GarbageCollectImagesResponse, error)
+	// PrewarmStatus returns the progress of the images pulled in the
+	// background before any pod uses them.
+	PrewarmStatus(ctx context.Context, in *PrewarmStatusRequest, opts ...grpc.CallOption) (*PrewarmStatusResponse, error)
+}
+
+type extensionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtensionServiceClient(cc grpc.ClientConnInterface) ExtensionServiceClient {
+	return &extensionServiceClient{cc}
+}
+
+func (c *extensionServiceClient) SobeyPodSandboxStats(ctx context.Context, in *SobeyPodSandboxStatsRequest, opts ...grpc.CallOption) (*SobeyPodSandboxStatsResponse, error) {
+	out := new(SobeyPodSandboxStatsResponse)
+	err := c.cc.Invoke(ctx, "/runtime.sobey.v1.ExtensionService/SobeyPodSandboxStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extensionServiceClient) ListSobeyPodSandboxStats(ctx context.Context, in *ListSobeyPodSandboxStatsRequest, opts ...grpc.CallOption) (*ListSobeyPodSandboxStatsResponse, error) {
+	out := new(ListSobeyPodSandboxStatsResponse)
+	err := c.cc.Invoke(ctx, "/runtime.sobey.v1.ExtensionService/ListSobeyPodSandboxStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extensionServiceClient) GarbageCollectImages(ctx context.Context, in *GarbageCollectImagesRequest, opts ...grpc.CallOption) (*GarbageCollectImagesResponse, error) {
+	out := new(GarbageCollectImagesResponse)
+	err := c.cc.Invoke(ctx, "/runtime.sobey.v1.ExtensionService/GarbageCollectImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extensionServiceClient) PrewarmStatus(ctx context.Context, in *PrewarmStatusRequest, opts ...grpc.CallOption) (*PrewarmStatusResponse, error) {
+	out := new(PrewarmStatusResponse)
+	err := c.cc.Invoke(ctx, "/runtime.sobey.v1.ExtensionService/PrewarmStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtensionServiceServer is the server API for ExtensionService service.
+// All implementations should embed UnimplementedExtensionServiceServer
+// for forward compatibility
+type ExtensionServiceServer interface {
+	// SobeyPodSandboxStats returns the stats of the pod sandbox. The Sobey
+	// prefix keeps the method apart from the CRI RuntimeService methods served
+	// by the same receiver.
+	SobeyPodSandboxStats(context.Context, *SobeyPodSandboxStatsRequest) (*SobeyPodSandboxStatsResponse, error)
+	// ListSobeyPodSandboxStats returns the stats of the pod sandboxes matching a filter.
+	ListSobeyPodSandboxStats(context.Context, *ListSobeyPodSandboxStatsRequest) (*ListSobeyPodSandboxStatsResponse, error)
+	// GarbageCollectImages removes the unused images to bring the usage of
+	// the image filesystem down to the low threshold.
+	GarbageCollectImages(context.Context, *GarbageCollectImagesRequest) (*GarbageCollectImagesResponse, error)
+	// PrewarmStatus returns the progress of the images pulled in the
+	// background before any pod uses them.
+	PrewarmStatus(context.Context, *PrewarmStatusRequest) (*PrewarmStatusResponse, error)
+}
+
+// UnimplementedExtensionServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedExtensionServiceServer struct {
+}
+
+func (UnimplementedExtensionServiceServer) SobeyPodSandboxStats(context.Context, *SobeyPodSandboxStatsRequest) (*SobeyPodSandboxStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SobeyPodSandboxStats not implemented")
+}
+func (UnimplementedExtensionServiceServer) ListSobeyPodSandboxStats(context.Context, *ListSobeyPodSandboxStatsRequest) (*ListSobeyPodSandboxStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSobeyPodSandboxStats not implemented")
+}
+func (UnimplementedExtensionServiceServer) GarbageCollectImages(context.Context, *GarbageCollectImagesRequest) (*GarbageCollectImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GarbageCollectImages not implemented")
+}
+func (UnimplementedExtensionServiceServer) PrewarmStatus(context.Context, *PrewarmStatusRequest) (*PrewarmStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrewarmStatus not implemented")
+}
+
+// UnsafeExtensionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtensionServiceServer will
+// result in compilation errors.
+type UnsafeExtensionServiceServer interface {
+	mustEmbedUnimplementedExtensionServiceServer()
+}
+
+func RegisterExtensionServiceServer(s grpc.ServiceRegistrar, srv ExtensionServiceServer) {
+	s.RegisterService(&ExtensionService_ServiceDesc, srv)
+}
+
+func _ExtensionService_SobeyPodSandboxStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SobeyPodSandboxStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtensionServiceServer).SobeyPodSandboxStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.sobey.v1.ExtensionService/SobeyPodSandboxStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtensionServiceServer).SobeyPodSandboxStats(ctx, req.(*SobeyPodSandboxStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtensionService_ListSobeyPodSandboxStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSobeyPodSandboxStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtensionServiceServer).ListSobeyPodSandboxStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.sobey.v1.ExtensionService/ListSobeyPodSandboxStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtensionServiceServer).ListSobeyPodSandboxStats(ctx, req.(*ListSobeyPodSandboxStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtensionService_GarbageCollectImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GarbageCollectImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtensionServiceServer).GarbageCollectImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.sobey.v1.ExtensionService/GarbageCollectImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtensionServiceServer).GarbageCollectImages(ctx, req.(*GarbageCollectImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtensionService_PrewarmStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrewarmStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtensionServiceServer).PrewarmStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.sobey.v1.ExtensionService/PrewarmStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtensionServiceServer).PrewarmStatus(ctx, req.(*PrewarmStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtensionService_ServiceDesc is the grpc.ServiceDesc for ExtensionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtensionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "runtime.sobey.v1.ExtensionService",
+	HandlerType: (*ExtensionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SobeyPodSandboxStats",
+			Handler:    _ExtensionService_SobeyPodSandboxStats_Handler,
+		},
+		{
+			MethodName: "ListSobeyPodSandboxStats",
+			Handler:    _ExtensionService_ListSobeyPodSandboxStats_Handler,
+		},
+		{
+			MethodName: "GarbageCollectImages",
+			Handler:    _ExtensionService_GarbageCollectImages_Handler,
+		},
+		{
+			MethodName: "PrewarmStatus",
+			Handler:    _ExtensionService_PrewarmStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/api.proto",
+}
@@ -0,0 +1,162 @@
+// Package cgroups locates the cgroup of a container process and manages its
+// resources, on both cgroup v1 and cgroup v2 (unified) hosts.
+package cgroups
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	util "sobey-runtime/utils"
+	"strconv"
+	"strings"
+)
+
+// Cgroup is the set of cgroup directories a process belongs to.
+type Cgroup struct {
+	// Unified is the directory of the process in the cgroup v2 hierarchy,
+	// it is only set on cgroup v2 hosts.
+	Unified string
+	// Paths maps every cgroup v1 controller to the directory of the process
+	// in the hierarchy of that controller.
+	Paths map[string]string
+}
+
+// IsUnified tells whether the cgroup is in the cgroup v2 hierarchy.
+func (c *Cgroup) IsUnified() bool {
+	return len(c.Unified) != 0
+}
+
+// Load returns the cgroup of the process with the pid.
+func Load(pid string) (*Cgroup, error) {
+	pid = strings.TrimSpace(pid)
+	cgroupFile, err := os.Open(fmt.Sprintf("/proc/%s/cgroup", pid))
+	if err != nil {
+		return nil, err
+	}
+	defer cgroupFile.Close()
+	cgroupPaths, err := parseCgroupFile(cgroupFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cgroup of process %s: %v", pid, err)
+	}
+
+	mountInfo, err := util.ReadMountInfo("self")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cgroup mounts: %v", err)
+	}
+	return newCgroup(cgroupPaths, cgroupMounts(mountInfo))
+}
+
+func newCgroup(cgroupPaths map[string]string, mounts map[string]mount) (*Cgroup, error) {
+	cgroup := &Cgroup{Paths: make(map[string]string)}
+	for controller, path := range cgroupPaths {
+		if len(controller) == 0 {
+			continue
+		}
+		if m, ok := mounts[controller]; ok {
+			cgroup.Paths[controller] = m.join(path)
+		}
+	}
+	// Hybrid hosts mount an empty cgroup v2 hierarchy next to the v1
+	// controllers, the v1 hierarchies are the ones holding the limits.
+	if len(cgroup.Paths) != 0 {
+		return cgroup, nil
+	}
+	path, ok := cgroupPaths[""]
+	m, mounted := mounts[""]
+	if !ok || !mounted {
+		return nil, fmt.Errorf("no mounted cgroup hierarchy found")
+	}
+	cgroup.Unified = m.join(path)
+	return cgroup, nil
+}
+
+// parseCgroupFile parses /proc/<pid>/cgroup into a map from controller to
+// cgroup path, the cgroup v2 path is stored under the empty controller.
+func parseCgroupFile(r io.Reader) (map[string]string, error) {
+	cgroupPaths := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if len(parts[1]) == 0 {
+			cgroupPaths[""] = parts[2]
+			continue
+		}
+		for _, controller := range strings.Split(parts[1], ",") {
+			cgroupPaths[strings.TrimPrefix(controller, "name=")] = parts[2]
+		}
+	}
+	return cgroupPaths, scanner.Err()
+}
+
+// mount is where a cgroup hierarchy is mounted, root is the cgroup path of
+// the mounted directory within the hierarchy.
+type mount struct {
+	root       string
+	mountPoint string
+}
+
+func (m mount) join(path string) string {
+	if m.root != "/" {
+		path = strings.TrimPrefix(path, m.root)
+	}
+	return filepath.Join(m.mountPoint, path)
+}
+
+// cgroupMounts maps every controller to the mount of its hierarchy, the
+// cgroup v2 mount is stored under the empty controller.
+func cgroupMounts(mountInfo []util.MountInfo) map[string]mount {
+	mounts := make(map[string]mount)
+	for _, info := range mountInfo {
+		m := mount{root: info.Root, mountPoint: info.MountPoint}
+		switch info.FSType {
+		case "cgroup2":
+			mounts[""] = m
+		case "cgroup":
+			for _, option := range info.SuperOptions {
+				mounts[strings.TrimPrefix(option, "name=")] = m
+			}
+		}
+	}
+	return mounts
+}
+
+func writeFile(dir, file, value string) error {
+	path := filepath.Join(dir, file)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err = f.WriteString(value); err != nil {
+		return fmt.Errorf("failed to write %q to %s: %v", value, path, err)
+	}
+	return nil
+}
+
+func readFile(dir, file string) (string, error) {
+	bytes, err := ioutil.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(bytes)), nil
+}
+
+// readUint reads a file holding a single number, "max" is read as the
+// largest value.
+func readUint(dir, file string) (uint64, error) {
+	value, err := readFile(dir, file)
+	if err != nil {
+		return 0, err
+	}
+	if value == "max" {
+		return ^uint64(0), nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
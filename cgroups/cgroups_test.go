@@ -0,0 +1,139 @@
+package cgroups
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sobey-runtime/module"
+	util "sobey-runtime/utils"
+	"strings"
+	"testing"
+)
+
+const (
+	cgroupV1File = `12:memory:/kubepods/pod1/abc
+11:cpu,cpuacct:/kubepods/pod1/abc
+1:name=systemd:/kubepods/pod1/abc
+0::/
+`
+	mountInfoV1 = `25 30 0:23 / /sys/fs/cgroup ro,nosuid shared:9 - tmpfs tmpfs ro,mode=755
+26 25 0:24 / /sys/fs/cgroup/unified rw,nosuid shared:10 - cgroup2 cgroup2 rw,nsdelegate
+33 25 0:31 / /sys/fs/cgroup/memory rw,nosuid shared:16 - cgroup cgroup rw,memory
+34 25 0:32 / /sys/fs/cgroup/cpu,cpuacct rw,nosuid shared:17 - cgroup cgroup rw,cpu,cpuacct
+35 25 0:33 /kubepods /sys/fs/cgroup/systemd rw,nosuid shared:18 - cgroup cgroup rw,name=systemd
+`
+	cgroupV2File = "0::/kubepods.slice/abc.scope\n"
+	mountInfoV2  = "25 30 0:23 / /sys/fs/cgroup rw,nosuid shared:4 - cgroup2 cgroup2 rw,nsdelegate\n"
+)
+
+func TestLoadV1(t *testing.T) {
+	cgroupPaths, err := parseCgroupFile(strings.NewReader(cgroupV1File))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mountInfo, err := util.ParseMountInfo(strings.NewReader(mountInfoV1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cgroup, err := newCgroup(cgroupPaths, cgroupMounts(mountInfo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cgroup.IsUnified() {
+		t.Fatalf("hybrid host is loaded as cgroup v2")
+	}
+	expected := map[string]string{
+		"memory":  "/sys/fs/cgroup/memory/kubepods/pod1/abc",
+		"cpu":     "/sys/fs/cgroup/cpu,cpuacct/kubepods/pod1/abc",
+		"cpuacct": "/sys/fs/cgroup/cpu,cpuacct/kubepods/pod1/abc",
+		"systemd": "/sys/fs/cgroup/systemd/pod1/abc",
+	}
+	for controller, path := range expected {
+		if cgroup.Paths[controller] != path {
+			t.Errorf("expected %s for controller %s, got %s", path, controller, cgroup.Paths[controller])
+		}
+	}
+}
+
+func TestLoadV2(t *testing.T) {
+	cgroupPaths, err := parseCgroupFile(strings.NewReader(cgroupV2File))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mountInfo, err := util.ParseMountInfo(strings.NewReader(mountInfoV2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cgroup, err := newCgroup(cgroupPaths, cgroupMounts(mountInfo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cgroup.Unified != "/sys/fs/cgroup/kubepods.slice/abc.scope" {
+		t.Fatalf("unexpected cgroup v2 path %s", cgroup.Unified)
+	}
+}
+
+func TestUpdateV2(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"cpu.weight", "cpu.max", "memory.max", "memory.swap.max", "pids.max"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, file), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cgroup := &Cgroup{Unified: dir}
+	err := cgroup.Update(&module.Resource{
+		CpuShares:              1024,
+		CpuQuota:               50000,
+		CpuPeriod:              100000,
+		MemoryLimitInBytes:     256 << 20,
+		MemorySwapLimitInBytes: 512 << 20,
+		Unified:                map[string]string{"pids.max": "64"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"cpu.weight":      "39",
+		"cpu.max":         "50000 100000",
+		"memory.max":      "268435456",
+		"memory.swap.max": "268435456",
+		"pids.max":        "64",
+	}
+	for file, value := range expected {
+		content, err := readFile(dir, file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if content != value {
+			t.Errorf("expected %q in %s, got %q", value, file, content)
+		}
+	}
+}
+
+func TestStatsV2(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"cpu.stat":       "usage_usec 1500\nuser_usec 1000\nsystem_usec 500\n",
+		"memory.current": "1048576\n",
+		"memory.stat":    "anon 524288\ninactive_file 262144\npgfault 12\npgmajfault 3\n",
+	}
+	for file, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats, err := (&Cgroup{Unified: dir}).Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Stats{
+		CpuUsageNanos:    1500000,
+		MemoryUsageBytes: 1048576,
+		WorkingSetBytes:  786432,
+		RssBytes:         524288,
+		PageFaults:       12,
+		MajorPageFaults:  3,
+	}
+	if *stats != expected {
+		t.Fatalf("expected %+v, got %+v", expected, *stats)
+	}
+}
@@ -0,0 +1,141 @@
+package cgroups
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Stats is the resource usage of a cgroup.
+type Stats struct {
+	// Cumulative CPU time consumed by the cgroup in nanoseconds.
+	CpuUsageNanos uint64
+	// Memory usage including the page cache.
+	MemoryUsageBytes uint64
+	// Memory usage minus the inactive page cache, which is what the kubelet
+	// evicts and OOM kills on.
+	WorkingSetBytes uint64
+	// Anonymous memory and swap cache.
+	RssBytes        uint64
+	PageFaults      uint64
+	MajorPageFaults uint64
+}
+
+// Stats reads the current resource usage of the cgroup.
+func (c *Cgroup) Stats() (*Stats, error) {
+	if c.IsUnified() {
+		return statsV2(c.Unified)
+	}
+	return c.statsV1()
+}
+
+func (c *Cgroup) statsV1() (*Stats, error) {
+	stats := new(Stats)
+	dir, ok := c.Paths["cpuacct"]
+	if !ok {
+		return nil, fmt.Errorf("cpuacct controller is not mounted")
+	}
+	cpuUsage, err := readUint(dir, "cpuacct.usage")
+	if err != nil {
+		return nil, err
+	}
+	stats.CpuUsageNanos = cpuUsage
+
+	dir, ok = c.Paths["memory"]
+	if !ok {
+		return nil, fmt.Errorf("memory controller is not mounted")
+	}
+	stats.MemoryUsageBytes, err = readUint(dir, "memory.usage_in_bytes")
+	if err != nil {
+		return nil, err
+	}
+	memoryStat, err := readKeyValues(dir, "memory.stat")
+	if err != nil {
+		return nil, err
+	}
+	stats.WorkingSetBytes = workingSet(stats.MemoryUsageBytes, memoryStat["total_inactive_file"])
+	stats.RssBytes = memoryStat["total_rss"]
+	stats.PageFaults = memoryStat["total_pgfault"]
+	stats.MajorPageFaults = memoryStat["total_pgmajfault"]
+	return stats, nil
+}
+
+func statsV2(dir string) (*Stats, error) {
+	stats := new(Stats)
+	cpuStat, err := readKeyValues(dir, "cpu.stat")
+	if err != nil {
+		return nil, err
+	}
+	stats.CpuUsageNanos = cpuStat["usage_usec"] * 1000
+
+	stats.MemoryUsageBytes, err = readUint(dir, "memory.current")
+	if err != nil {
+		return nil, err
+	}
+	memoryStat, err := readKeyValues(dir, "memory.stat")
+	if err != nil {
+		return nil, err
+	}
+	stats.WorkingSetBytes = workingSet(stats.MemoryUsageBytes, memoryStat["inactive_file"])
+	stats.RssBytes = memoryStat["anon"]
+	stats.PageFaults = memoryStat["pgfault"]
+	stats.MajorPageFaults = memoryStat["pgmajfault"]
+	return stats, nil
+}
+
+func workingSet(usage, inactiveFile uint64) uint64 {
+	if inactiveFile > usage {
+		return 0
+	}
+	return usage - inactiveFile
+}
+
+// readKeyValues reads a flat keyed file like memory.stat and cpu.stat.
+func readKeyValues(dir, file string) (map[string]uint64, error) {
+	f, err := os.Open(filepath.Join(dir, file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[fields[0]] = value
+	}
+	return values, scanner.Err()
+}
+
+// OOMKillCount returns how many processes of the cgroup the OOM killer killed.
+func (c *Cgroup) OOMKillCount() (uint64, error) {
+	if c.IsUnified() {
+		events, err := readKeyValues(c.Unified, "memory.events")
+		if err != nil {
+			return 0, err
+		}
+		return events["oom_kill"], nil
+	}
+	dir, ok := c.Paths["memory"]
+	if !ok {
+		return 0, fmt.Errorf("memory controller is not mounted")
+	}
+	oomControl, err := readKeyValues(dir, "memory.oom_control")
+	if err != nil {
+		return 0, err
+	}
+	// Kernels before 4.13 only tell whether the cgroup is under OOM.
+	if count, ok := oomControl["oom_kill"]; ok {
+		return count, nil
+	}
+	return oomControl["under_oom"], nil
+}
@@ -0,0 +1,197 @@
+package cgroups
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sobey-runtime/module"
+	"strconv"
+)
+
+// Update applies the resources to the cgroup. Zero values are left unchanged
+// as in the CRI, OomScoreAdj is a process attribute and is not applied here.
+func (c *Cgroup) Update(resource *module.Resource) error {
+	if resource == nil {
+		return nil
+	}
+	if c.IsUnified() {
+		return updateV2(c.Unified, resource)
+	}
+	return c.updateV1(resource)
+}
+
+func (c *Cgroup) updateV1(resource *module.Resource) error {
+	if len(resource.Unified) != 0 {
+		return fmt.Errorf("unified resources are only supported on cgroup v2")
+	}
+	if dir, ok := c.Paths["cpu"]; ok {
+		if resource.CpuShares != 0 {
+			if err := writeFile(dir, "cpu.shares", strconv.FormatInt(resource.CpuShares, 10)); err != nil {
+				return err
+			}
+		}
+		if resource.CpuPeriod != 0 {
+			if err := writeFile(dir, "cpu.cfs_period_us", strconv.FormatInt(resource.CpuPeriod, 10)); err != nil {
+				return err
+			}
+		}
+		if resource.CpuQuota != 0 {
+			if err := writeFile(dir, "cpu.cfs_quota_us", strconv.FormatInt(resource.CpuQuota, 10)); err != nil {
+				return err
+			}
+		}
+	}
+	if dir, ok := c.Paths["cpuset"]; ok {
+		if err := updateCpuset(dir, resource); err != nil {
+			return err
+		}
+	}
+	if dir, ok := c.Paths["memory"]; ok {
+		if err := updateV1Memory(dir, resource.MemoryLimitInBytes, resource.MemorySwapLimitInBytes); err != nil {
+			return err
+		}
+	}
+	if dir, ok := c.Paths["hugetlb"]; ok {
+		for _, limit := range resource.HugepageLimits {
+			file := fmt.Sprintf("hugetlb.%s.limit_in_bytes", limit.PageSize)
+			if err := writeFile(dir, file, strconv.FormatUint(limit.Limit, 10)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// updateV1Memory sets the memory and the memory+swap limits. The kernel
+// rejects a memory limit above the memory+swap limit, so the limit which
+// grows is written first.
+func updateV1Memory(dir string, limit, swap int64) error {
+	setLimit := func() error {
+		if limit == 0 {
+			return nil
+		}
+		return writeFile(dir, "memory.limit_in_bytes", strconv.FormatInt(limit, 10))
+	}
+	setSwap := func() error {
+		if swap == 0 {
+			return nil
+		}
+		// Swap accounting may be disabled on the host.
+		if _, err := os.Stat(filepath.Join(dir, "memory.memsw.limit_in_bytes")); os.IsNotExist(err) {
+			return nil
+		}
+		return writeFile(dir, "memory.memsw.limit_in_bytes", strconv.FormatInt(swap, 10))
+	}
+
+	current, err := readUint(dir, "memory.limit_in_bytes")
+	if err != nil {
+		return err
+	}
+	if limit == -1 || (limit > 0 && uint64(limit) > current) {
+		if err = setSwap(); err != nil {
+			return err
+		}
+		return setLimit()
+	}
+	if err = setLimit(); err != nil {
+		return err
+	}
+	return setSwap()
+}
+
+func updateV2(dir string, resource *module.Resource) error {
+	if resource.CpuShares != 0 {
+		weight := cpuSharesToWeight(resource.CpuShares)
+		if err := writeFile(dir, "cpu.weight", strconv.FormatInt(weight, 10)); err != nil {
+			return err
+		}
+	}
+	if resource.CpuQuota != 0 || resource.CpuPeriod != 0 {
+		value := "max"
+		if resource.CpuQuota > 0 {
+			value = strconv.FormatInt(resource.CpuQuota, 10)
+		}
+		if resource.CpuPeriod != 0 {
+			value = fmt.Sprintf("%s %d", value, resource.CpuPeriod)
+		}
+		if err := writeFile(dir, "cpu.max", value); err != nil {
+			return err
+		}
+	}
+	if err := updateCpuset(dir, resource); err != nil {
+		return err
+	}
+	if resource.MemoryLimitInBytes != 0 {
+		if err := writeFile(dir, "memory.max", limitToV2(resource.MemoryLimitInBytes)); err != nil {
+			return err
+		}
+	}
+	if resource.MemorySwapLimitInBytes != 0 {
+		swap, err := swapToV2(resource.MemoryLimitInBytes, resource.MemorySwapLimitInBytes)
+		if err != nil {
+			return err
+		}
+		if err = writeFile(dir, "memory.swap.max", swap); err != nil {
+			return err
+		}
+	}
+	for _, limit := range resource.HugepageLimits {
+		file := fmt.Sprintf("hugetlb.%s.max", limit.PageSize)
+		if err := writeFile(dir, file, strconv.FormatUint(limit.Limit, 10)); err != nil {
+			return err
+		}
+	}
+	// The unified resources are raw cgroup v2 files, they override the
+	// values above.
+	for file, value := range resource.Unified {
+		if filepath.Base(file) != file {
+			return fmt.Errorf("invalid unified resource %q", file)
+		}
+		if err := writeFile(dir, file, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func updateCpuset(dir string, resource *module.Resource) error {
+	if len(resource.CpusetCpus) != 0 {
+		if err := writeFile(dir, "cpuset.cpus", resource.CpusetCpus); err != nil {
+			return err
+		}
+	}
+	if len(resource.CpusetMems) != 0 {
+		if err := writeFile(dir, "cpuset.mems", resource.CpusetMems); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cpuSharesToWeight converts cgroup v1 cpu shares in [2, 262144] to the
+// cgroup v2 cpu weight in [1, 10000].
+func cpuSharesToWeight(shares int64) int64 {
+	return 1 + ((shares-2)*9999)/262142
+}
+
+func limitToV2(limit int64) string {
+	if limit < 0 {
+		return "max"
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+// swapToV2 converts the memory+swap limit of the CRI to the swap only limit
+// of cgroup v2.
+func swapToV2(limit, swap int64) (string, error) {
+	if swap < 0 {
+		return "max", nil
+	}
+	if limit <= 0 {
+		return "", fmt.Errorf("memory swap limit %d requires a memory limit", swap)
+	}
+	if swap < limit {
+		return "", fmt.Errorf("memory swap limit %d is lower than the memory limit %d", swap, limit)
+	}
+	return strconv.FormatInt(swap-limit, 10), nil
+}
@@ -16,18 +16,31 @@ const (
 	KubernetesPodUIDLabel        = "io.kubernetes.pod.uid"
 	KubernetesContainerNameLabel = "io.kubernetes.container.name"
 
+	KubernetesContainerTerminationMessagePathLabel   = "io.kubernetes.container.terminationMessagePath"
+	KubernetesContainerTerminationMessagePolicyLabel = "io.kubernetes.container.terminationMessagePolicy"
+	TerminationMessageFallbackToLogsOnError          = "FallbackToLogsOnError"
+
 	ServerLogDirPath        = "/var/lib/sobey/servers/log/"
 	KubernetesPodLogDirPath = "/var/log/pods/"
 
 	SandboxIDPrefix   = "sandbox"
 	ContainerIDPrefix = "container"
+	// IPAMPrefix prefixes the IP allocation state of each node.
+	IPAMPrefix = "ipam"
 
 	SockerHomePath   = "/var/lib/socker"
 	SockerTempPath   = SockerHomePath + "/tmp"
 	SockerImagesPath = SockerHomePath + "/images"
+	// SockerImageIndexPath is the metadata index of the images in SockerImagesPath.
+	SockerImageIndexPath = SockerHomePath + "/images.json"
 
 	SockerContainerHome     = "/var/run/socker/containers/%s"
 	SockerContainerFSHome   = "/var/run/socker/containers/%s/fs"
 	SockerContainerConfHome = "/var/run/socker/containers/%s/conf"
 	SockerContainerPidHome  = "/var/run/socker/containers/%s/pid"
+
+	SockerContainerExitHome       = "/var/run/socker/containers/%s/exit"
+	SockerContainerAttachHome     = "/var/run/socker/containers/%s/attach"
+	SockerContainerMonitorLogHome = "/var/run/socker/containers/%s/monitor.log"
+	SockerContainerMonitorPidHome = "/var/run/socker/containers/%s/monitor.pid"
 )
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/viper"
 	"reflect"
 	"sort"
+	"strings"
 )
 
 type Etcd struct {
@@ -26,6 +27,69 @@ type Server struct {
 	Polling []int     `json:"polling" mapstructure:"polling"`
 }
 
+type Streaming struct {
+	Addr string `json:"addr" mapstructure:"addr"`
+}
+
+type ContainerLog struct {
+	MaxSize  int64 `json:"maxSize" mapstructure:"maxSize"`
+	MaxFiles int   `json:"maxFiles" mapstructure:"maxFiles"`
+}
+
+type ImageGC struct {
+	HighThresholdPercent int `json:"highThresholdPercent" mapstructure:"highThresholdPercent"`
+	LowThresholdPercent  int `json:"lowThresholdPercent" mapstructure:"lowThresholdPercent"`
+	// Seconds between two checks of the disk usage.
+	Period int `json:"period" mapstructure:"period"`
+}
+
+type Prewarm struct {
+	Images []string `json:"images" mapstructure:"images"`
+	// Etcd key holding a json list of images pulled besides Images.
+	EtcdKey string `json:"etcdKey" mapstructure:"etcdKey"`
+	// Number of images pulled at the same time.
+	Concurrency int `json:"concurrency" mapstructure:"concurrency"`
+	// Seconds between two checks of the images, the missing ones are pulled
+	// again.
+	Period int `json:"period" mapstructure:"period"`
+}
+
+// ImageSource is a repository the images are pulled from, a http(s) server
+// or a local directory with a file:// url.
+type ImageSource struct {
+	Name string `json:"name" mapstructure:"name"`
+	Url  string `json:"url" mapstructure:"url"`
+	// CA certificates trusted besides the system ones.
+	CaFile string `json:"caFile" mapstructure:"caFile"`
+	// Prefixes of the names of the images pulled from the source, every
+	// image is when empty.
+	Prefixes []string    `json:"prefixes" mapstructure:"prefixes"`
+	Verify   ImageVerify `json:"verify" mapstructure:"verify"`
+}
+
+// ImageVerify is the verification policy of an image source. Checksum and
+// Signature are one of "required", "optional" and "none".
+type ImageVerify struct {
+	// Verification against the .sha256 or .sha1 file next to the image,
+	// required by default.
+	Checksum string `json:"checksum" mapstructure:"checksum"`
+	// Verification of the detached .sig signature next to the image against
+	// the public keys in Keyring, optional by default so it only runs when
+	// Keyring is set and the signature is published.
+	Signature string `json:"signature" mapstructure:"signature"`
+	// Directory of PEM encoded public keys.
+	Keyring string `json:"keyring" mapstructure:"keyring"`
+}
+
+// Credential authenticates the pulls from the image repositories whose URL
+// starts with Repo, with the token as a bearer token when it is set.
+type Credential struct {
+	Repo     string `json:"repo" mapstructure:"repo"`
+	Username string `json:"username" mapstructure:"username"`
+	Password string `json:"password" mapstructure:"password"`
+	Token    string `json:"token" mapstructure:"token"`
+}
+
 type ServerApi struct {
 	Run     string `json:"run" mapstructure:"run"`
 	Stop    string `json:"stop" mapstructure:"stop"`
@@ -33,6 +97,12 @@ type ServerApi struct {
 	List    string `json:"list" mapstructure:"list"`
 }
 
+// secretConfKeys are the config sections holding secrets, which are never
+// printed.
+var secretConfKeys = map[string]bool{
+	"credentials": true,
+}
+
 func InitConf() error {
 	cond := flag.String("config", ".", "config dir, config file type is yaml")
 	flag.Parse()
@@ -47,6 +117,10 @@ func InitConf() error {
 	keys := viper.AllKeys()
 	sort.Strings(keys)
 	for _, key := range keys {
+		if secretConfKeys[strings.Split(key, ".")[0]] {
+			fmt.Printf("%s = <redacted>\n", key)
+			continue
+		}
 		fmt.Printf("%s = %v\n", key, viper.Get(key))
 	}
 	return err
@@ -67,6 +141,104 @@ func InitServerConf() *Server {
 	return server
 }
 
+// InitStreamingConf returns the config of the exec/attach/port-forward
+// streaming server, it listens on localhost with a random port by default.
+func InitStreamingConf() *Streaming {
+	streaming := &Streaming{Addr: "127.0.0.1:0"}
+	streamingConfMap := viper.GetStringMap("streaming")
+	if len(streamingConfMap) == 0 {
+		return streaming
+	}
+	err := ParseInterface2Struct(streamingConfMap, &streaming)
+	if err != nil {
+		fmt.Printf("Parse confStr : %+v to struct err , err : %+v", streamingConfMap, err)
+		return nil
+	}
+	return streaming
+}
+
+// InitContainerLogConf returns the config of the container log files under
+// the server log dir. Size based rotation is disabled by default, and five
+// rotated files are kept for every container.
+func InitContainerLogConf() *ContainerLog {
+	containerLog := &ContainerLog{MaxFiles: 5}
+	containerLogConfMap := viper.GetStringMap("containerLog")
+	if len(containerLogConfMap) == 0 {
+		return containerLog
+	}
+	err := ParseInterface2Struct(containerLogConfMap, &containerLog)
+	if err != nil {
+		fmt.Printf("Parse confStr : %+v to struct err , err : %+v", containerLogConfMap, err)
+		return nil
+	}
+	return containerLog
+}
+
+// InitImageGCConf returns the config of the image garbage collection. Like
+// the kubelet, unused images are removed once the image filesystem is 85%
+// used until the usage is under 80%, the usage is checked every five minutes.
+// Unlike a missing section, a section which fails to parse is an error.
+func InitImageGCConf() (*ImageGC, error) {
+	imageGC := &ImageGC{HighThresholdPercent: 85, LowThresholdPercent: 80, Period: 300}
+	imageGCConfMap := viper.GetStringMap("imageGC")
+	if len(imageGCConfMap) == 0 {
+		return imageGC, nil
+	}
+	err := ParseInterface2Struct(imageGCConfMap, &imageGC)
+	if err != nil {
+		return nil, fmt.Errorf("parse imageGC config %+v failed, err: %v", imageGCConfMap, err)
+	}
+	return imageGC, nil
+}
+
+// InitPrewarmConf returns the config of the images pulled in the background
+// before any pod uses them. Nothing is pulled by default, two images are
+// pulled at the same time and the missing ones are checked every five
+// minutes.
+func InitPrewarmConf() *Prewarm {
+	prewarm := &Prewarm{Concurrency: 2, Period: 300}
+	prewarmConfMap := viper.GetStringMap("prewarm")
+	if len(prewarmConfMap) == 0 {
+		return prewarm
+	}
+	err := ParseInterface2Struct(prewarmConfMap, &prewarm)
+	if err != nil {
+		fmt.Printf("Parse confStr : %+v to struct err , err : %+v", prewarmConfMap, err)
+		return nil
+	}
+	return prewarm
+}
+
+// InitImageSourcesConf returns the image sources tried in order by the
+// pulls. Without them the images are pulled from the repo of the server.
+func InitImageSourcesConf() []ImageSource {
+	var imageSources []ImageSource
+	if !viper.IsSet("imageSources") {
+		return imageSources
+	}
+	err := viper.UnmarshalKey("imageSources", &imageSources)
+	if err != nil {
+		fmt.Printf("Parse image sources err , err : %+v", err)
+		return nil
+	}
+	return imageSources
+}
+
+// InitCredentialsConf returns the credentials of the image repositories,
+// the pulls are anonymous by default.
+func InitCredentialsConf() []Credential {
+	var credentials []Credential
+	if !viper.IsSet("credentials") {
+		return credentials
+	}
+	err := viper.UnmarshalKey("credentials", &credentials)
+	if err != nil {
+		fmt.Printf("Parse credentials err , err : %+v", err)
+		return nil
+	}
+	return credentials
+}
+
 func InitEtcdConf() *Etcd {
 	etcd := new(Etcd)
 	dbConfMap := viper.GetStringMap("etcd")
@@ -6,6 +6,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
 	"io/ioutil"
 	"k8s.io/klog/v2"
 	"sobey-runtime/config"
@@ -14,6 +15,9 @@ import (
 
 var db *clientv3.Client
 
+// pingKey is read to check the connection to etcd, it does not need to exist.
+const pingKey = "health"
+
 // DBService ...
 type DBService struct {
 }
@@ -58,6 +62,17 @@ func InitEtcd(conf *config.Etcd) error {
 	return err
 }
 
+// Ping checks that the etcd cluster can serve reads.
+func (ds *DBService) Ping() error {
+	if db == nil {
+		return fmt.Errorf("etcd client is not initialized")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(3)*time.Second)
+	defer cancel()
+	_, err := db.Get(ctx, pingKey, clientv3.WithCountOnly())
+	return err
+}
+
 func (ds *DBService) Put(key, val string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(3)*time.Second)
 	defer cancel()
@@ -139,3 +154,49 @@ func (ds *DBService) GetByPrefix(prefix string) ([]string, error) {
 	}
 	return results, err
 }
+
+// Txn runs apply as one serializable transaction, the reads and the writes
+// apply makes through stm commit together. apply runs again when another
+// writer changed the keys it read in between, so it must have no other side
+// effects.
+func (ds *DBService) Txn(apply func(stm concurrency.STM) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(5)*time.Second)
+	defer cancel()
+	_, err := concurrency.NewSTM(db, apply, concurrency.WithAbortContext(ctx))
+	if err != nil {
+		klog.ErrorS(err, "failed to run transaction")
+		return err
+	}
+	return err
+}
+
+// Watch sends the new values of the key, an empty value when it is deleted.
+// The channel is closed when ctx is done or the watch fails.
+func (ds *DBService) Watch(ctx context.Context, key string) <-chan string {
+	values := make(chan string)
+	if db == nil {
+		close(values)
+		return values
+	}
+	go func() {
+		defer close(values)
+		for watchResp := range db.Watch(ctx, key) {
+			if err := watchResp.Err(); err != nil {
+				klog.ErrorS(err, "failed to watch record", "key", key)
+				return
+			}
+			for _, event := range watchResp.Events {
+				value := ""
+				if event.Type == clientv3.EventTypePut {
+					value = string(event.Kv.Value)
+				}
+				select {
+				case values <- value:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return values
+}
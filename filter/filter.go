@@ -0,0 +1,74 @@
+// Package filter implements the filters of the CRI list calls the way
+// containerd does: every field set in a filter has to match, an ID matches
+// by prefix, and every entry of the label selector has to be one of the
+// labels.
+package filter
+
+import (
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	util "sobey-runtime/utils"
+	"strings"
+)
+
+// PodSandboxes returns the pod sandboxes matching the filter.
+func PodSandboxes(filter *runtimeapi.PodSandboxFilter, podSandboxes []*runtimeapi.PodSandbox) []*runtimeapi.PodSandbox {
+	if filter == nil {
+		return podSandboxes
+	}
+	var items []*runtimeapi.PodSandbox
+	for _, item := range podSandboxes {
+		if !MatchID(util.RemoveSandboxIDPrefix(filter.Id), util.RemoveSandboxIDPrefix(item.Id)) {
+			continue
+		}
+		if filter.State != nil && filter.State.State != item.State {
+			continue
+		}
+		if !MatchLabels(filter.LabelSelector, item.Labels) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// Containers returns the containers matching the filter.
+func Containers(filter *runtimeapi.ContainerFilter, containers []*runtimeapi.Container) []*runtimeapi.Container {
+	if filter == nil {
+		return containers
+	}
+	var items []*runtimeapi.Container
+	for _, item := range containers {
+		if !MatchID(util.RemoveContainerIDPrefix(filter.Id), util.RemoveContainerIDPrefix(item.Id)) {
+			continue
+		}
+		if len(filter.PodSandboxId) != 0 &&
+			util.RemoveSandboxIDPrefix(filter.PodSandboxId) != util.RemoveSandboxIDPrefix(item.PodSandboxId) {
+			continue
+		}
+		if filter.State != nil && filter.State.State != item.State {
+			continue
+		}
+		if !MatchLabels(filter.LabelSelector, item.Labels) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// MatchID tells whether the id matches the id of a filter, which may be a
+// prefix of it. An empty filter id matches every id.
+func MatchID(filterID, id string) bool {
+	return strings.HasPrefix(id, filterID)
+}
+
+// MatchLabels tells whether the labels have every key and value of the
+// selector.
+func MatchLabels(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if labelValue, ok := labels[key]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
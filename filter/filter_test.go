@@ -0,0 +1,128 @@
+package filter
+
+import (
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"testing"
+)
+
+func TestContainers(t *testing.T) {
+	containers := []*runtimeapi.Container{
+		{
+			Id:           "3f2a7c9d1e0b",
+			PodSandboxId: "a1b2c3d4e5f6",
+			State:        runtimeapi.ContainerState_CONTAINER_RUNNING,
+			Labels:       map[string]string{"app": "web", "io.kubernetes.container.name": "nginx"},
+		},
+		{
+			Id:           "3f2a11111111",
+			PodSandboxId: "a1b2c3d4e5f6",
+			State:        runtimeapi.ContainerState_CONTAINER_EXITED,
+			Labels:       map[string]string{"app": "web", "io.kubernetes.container.name": "init"},
+		},
+		{
+			Id:           "9c8b7a6f5e4d",
+			PodSandboxId: "0f9e8d7c6b5a",
+			State:        runtimeapi.ContainerState_CONTAINER_RUNNING,
+			Labels:       map[string]string{"app": "db"},
+		},
+	}
+	for _, tc := range []struct {
+		name     string
+		filter   *runtimeapi.ContainerFilter
+		expected []string
+	}{
+		{"nil filter", nil, []string{"3f2a7c9d1e0b", "3f2a11111111", "9c8b7a6f5e4d"}},
+		{"id prefix", &runtimeapi.ContainerFilter{Id: "3f2a"}, []string{"3f2a7c9d1e0b", "3f2a11111111"}},
+		{"sandbox", &runtimeapi.ContainerFilter{PodSandboxId: "0f9e8d7c6b5a"}, []string{"9c8b7a6f5e4d"}},
+		{"state", &runtimeapi.ContainerFilter{
+			State: &runtimeapi.ContainerStateValue{State: runtimeapi.ContainerState_CONTAINER_RUNNING},
+		}, []string{"3f2a7c9d1e0b", "9c8b7a6f5e4d"}},
+		{"all labels", &runtimeapi.ContainerFilter{
+			LabelSelector: map[string]string{"app": "web", "io.kubernetes.container.name": "init"},
+		}, []string{"3f2a11111111"}},
+		{"missing label", &runtimeapi.ContainerFilter{
+			LabelSelector: map[string]string{"tier": "frontend"},
+		}, nil},
+	} {
+		var ids []string
+		for _, container := range Containers(tc.filter, containers) {
+			ids = append(ids, container.Id)
+		}
+		if len(ids) != len(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tc.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, ids)
+				break
+			}
+		}
+	}
+}
+
+func TestPodSandboxes(t *testing.T) {
+	podSandboxes := []*runtimeapi.PodSandbox{
+		{
+			Id:     "a1b2c3d4e5f6",
+			State:  runtimeapi.PodSandboxState_SANDBOX_READY,
+			Labels: map[string]string{"app": "web", "io.kubernetes.pod.namespace": "default"},
+		},
+		{
+			Id:     "a1b2ffffffff",
+			State:  runtimeapi.PodSandboxState_SANDBOX_NOTREADY,
+			Labels: map[string]string{"app": "web", "io.kubernetes.pod.namespace": "kube-system"},
+		},
+		{
+			Id:     "0f9e8d7c6b5a",
+			State:  runtimeapi.PodSandboxState_SANDBOX_READY,
+			Labels: map[string]string{"app": "db"},
+		},
+	}
+	ready := &runtimeapi.PodSandboxStateValue{State: runtimeapi.PodSandboxState_SANDBOX_READY}
+	for _, tc := range []struct {
+		name     string
+		filter   *runtimeapi.PodSandboxFilter
+		expected []string
+	}{
+		{"nil filter", nil, []string{"a1b2c3d4e5f6", "a1b2ffffffff", "0f9e8d7c6b5a"}},
+		{"empty filter", &runtimeapi.PodSandboxFilter{}, []string{"a1b2c3d4e5f6", "a1b2ffffffff", "0f9e8d7c6b5a"}},
+		{"full id", &runtimeapi.PodSandboxFilter{Id: "0f9e8d7c6b5a"}, []string{"0f9e8d7c6b5a"}},
+		{"id prefix", &runtimeapi.PodSandboxFilter{Id: "a1b2"}, []string{"a1b2c3d4e5f6", "a1b2ffffffff"}},
+		{"unknown id", &runtimeapi.PodSandboxFilter{Id: "ffff"}, nil},
+		{"ready", &runtimeapi.PodSandboxFilter{State: ready}, []string{"a1b2c3d4e5f6", "0f9e8d7c6b5a"}},
+		{"not ready", &runtimeapi.PodSandboxFilter{
+			State: &runtimeapi.PodSandboxStateValue{State: runtimeapi.PodSandboxState_SANDBOX_NOTREADY},
+		}, []string{"a1b2ffffffff"}},
+		{"label", &runtimeapi.PodSandboxFilter{
+			LabelSelector: map[string]string{"app": "web"},
+		}, []string{"a1b2c3d4e5f6", "a1b2ffffffff"}},
+		{"label value mismatch", &runtimeapi.PodSandboxFilter{
+			LabelSelector: map[string]string{"app": "cache"},
+		}, nil},
+		{"id, state and label", &runtimeapi.PodSandboxFilter{
+			Id:            "a1b2",
+			State:         ready,
+			LabelSelector: map[string]string{"app": "web"},
+		}, []string{"a1b2c3d4e5f6"}},
+		{"state and label", &runtimeapi.PodSandboxFilter{
+			State:         ready,
+			LabelSelector: map[string]string{"io.kubernetes.pod.namespace": "kube-system"},
+		}, nil},
+	} {
+		var ids []string
+		for _, podSandbox := range PodSandboxes(tc.filter, podSandboxes) {
+			ids = append(ids, podSandbox.Id)
+		}
+		if len(ids) != len(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tc.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, ids)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package image
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Credential authenticates the requests to a repository, with the token as
+// a bearer token when it is set, otherwise with basic auth.
+type Credential struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// String keeps the secrets out of the logs.
+func (c Credential) String() string {
+	if c.Token != "" {
+		return "Credential{Token: <redacted>}"
+	}
+	return "Credential{Username: " + c.Username + ", Password: <redacted>}"
+}
+
+// GoString keeps the secrets out of the logs printed with %#v.
+func (c Credential) GoString() string {
+	return c.String()
+}
+
+// Empty tells whether the credential carries nothing to authenticate with.
+func (c *Credential) Empty() bool {
+	return c == nil || (c.Token == "" && c.Username == "" && c.Password == "")
+}
+
+func (c *Credential) apply(req *http.Request) {
+	if c.Empty() {
+		return
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+		return
+	}
+	req.SetBasicAuth(c.Username, c.Password)
+}
+
+// RepoCredential is the credential of the repositories under a URL prefix.
+type RepoCredential struct {
+	Prefix string
+	Credential
+}
+
+// MatchCredential returns the credential with the longest prefix of url, or
+// nil when none matches.
+func MatchCredential(credentials []RepoCredential, url string) *Credential {
+	var match *RepoCredential
+	for i := range credentials {
+		if !strings.HasPrefix(url, credentials[i].Prefix) {
+			continue
+		}
+		if match == nil || len(credentials[i].Prefix) > len(match.Prefix) {
+			match = &credentials[i]
+		}
+	}
+	if match == nil {
+		return nil
+	}
+	return &match.Credential
+}
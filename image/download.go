@@ -0,0 +1,226 @@
+package image
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// partialSuffix is the suffix of the downloads in progress in the temp
+	// directory.
+	partialSuffix = ".partial"
+	// validatorSuffix is the suffix of the file next to a partial download
+	// holding the ETag or Last-Modified of the content, so a resumed
+	// download is not stitched to a newer version of the artifact.
+	validatorSuffix = ".validator"
+	// maxPartialAge is how long an abandoned partial download is kept.
+	maxPartialAge = 24 * time.Hour
+)
+
+// download is a resumable download of an artifact into a partial file in
+// the temp directory.
+type download struct {
+	src  *Source
+	name string
+	cred *Credential
+	// Partial file holding the bytes received so far.
+	file *os.File
+	// Hashes of the bytes received so far.
+	hashes []hash.Hash
+}
+
+// partialPath returns the path of the partial download of the image from the
+// source.
+func (s *Store) partialPath(src *Source, name string) string {
+	sum := sha256.Sum256([]byte(src.URL + name))
+	return filepath.Join(s.tmp, hex.EncodeToString(sum[:16])+partialSuffix)
+}
+
+// openDownload opens the partial download of the image from the source and
+// feeds the bytes already received to the hashes.
+func (s *Store) openDownload(src *Source, name string, cred *Credential, hashes ...hash.Hash) (*download, error) {
+	if err := os.MkdirAll(s.tmp, 0750); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(s.partialPath(src, name), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	d := &download{src: src, name: name, cred: cred, file: f, hashes: hashes}
+	if _, err = io.Copy(d.writer(), f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *download) writer() io.Writer {
+	writers := make([]io.Writer, 0, len(d.hashes))
+	for _, h := range d.hashes {
+		writers = append(writers, h)
+	}
+	return io.MultiWriter(writers...)
+}
+
+// fetch downloads the rest of the artifact, resuming with a range request
+// when some bytes were received before. It starts over when the server does
+// not support ranges or the artifact changed since, and once more when the
+// server rejects the range of the partial file. Local files are copied as a
+// whole.
+func (d *download) fetch(ctx context.Context) error {
+	if d.src.dir != "" {
+		if err := d.reset(); err != nil {
+			return err
+		}
+		r, err := d.src.open(ctx, d.name, d.cred)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		_, err = io.Copy(io.MultiWriter(d.file, d.writer()), r)
+		return err
+	}
+
+	restart, err := d.fetchRange(ctx)
+	if !restart {
+		return err
+	}
+	klog.InfoS("Restart image download", "url", d.src.location(d.name), "err", err)
+	if err = d.reset(); err != nil {
+		return err
+	}
+	_ = os.Remove(d.file.Name() + validatorSuffix)
+	restart, err = d.fetchRange(ctx)
+	if restart {
+		return fmt.Errorf("download %s failed, err: %v", d.src.location(d.name), err)
+	}
+	return err
+}
+
+// fetchRange requests the bytes following the partial file, it tells
+// whether the server rejected the range so the download has to start over.
+func (d *download) fetchRange(ctx context.Context) (bool, error) {
+	offset, err := d.file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return false, err
+	}
+	location := d.src.location(d.name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return false, err
+	}
+	d.cred.apply(req)
+	validator := d.validator()
+	if offset > 0 && validator != "" {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", validator)
+	}
+	resp, err := d.src.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		// A complete partial file is answered with 416, a range other than
+		// the requested one can not be appended either.
+		contentRange := resp.Header.Get("Content-Range")
+		if !strings.HasPrefix(contentRange, fmt.Sprintf("bytes %d-", offset)) {
+			return true, fmt.Errorf("unexpected content range %q for offset %d", contentRange, offset)
+		}
+		klog.InfoS("Resume image download", "url", location, "offset", offset)
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		return true, fmt.Errorf("range from offset %d is not satisfiable", offset)
+	case resp.StatusCode == http.StatusOK:
+		if err = d.reset(); err != nil {
+			return false, err
+		}
+		d.saveValidator(resp.Header)
+	case resp.StatusCode == http.StatusNotFound:
+		return false, &notFoundError{location: location}
+	default:
+		return false, fmt.Errorf("download %s failed, status: %s", location, resp.Status)
+	}
+	_, err = io.Copy(io.MultiWriter(d.file, d.writer()), resp.Body)
+	return false, err
+}
+
+// reset drops the bytes received so far.
+func (d *download) reset() error {
+	if err := d.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	for _, h := range d.hashes {
+		h.Reset()
+	}
+	return nil
+}
+
+func (d *download) validator() string {
+	data, err := ioutil.ReadFile(d.file.Name() + validatorSuffix)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// saveValidator keeps the strong ETag, or the Last-Modified time, of the
+// artifact. Without any the download can not be resumed.
+func (d *download) saveValidator(header http.Header) {
+	validator := header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = header.Get("Last-Modified")
+	}
+	path := d.file.Name() + validatorSuffix
+	if validator == "" {
+		_ = os.Remove(path)
+		return
+	}
+	if err := ioutil.WriteFile(path, []byte(validator), 0644); err != nil {
+		klog.ErrorS(err, "Failed to save the validator of the download", "url", d.src.location(d.name))
+	}
+}
+
+// close closes the partial file, which is kept to resume the download.
+func (d *download) close() error {
+	return d.file.Close()
+}
+
+// discard removes the partial file.
+func (d *download) discard() {
+	d.file.Close()
+	_ = os.Remove(d.file.Name())
+	_ = os.Remove(d.file.Name() + validatorSuffix)
+}
+
+// prunePartials removes the partial downloads nobody resumed for a long time.
+func (s *Store) prunePartials() {
+	entries, err := ioutil.ReadDir(s.tmp)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), partialSuffix) || time.Since(entry.ModTime()) < maxPartialAge {
+			continue
+		}
+		path := filepath.Join(s.tmp, entry.Name())
+		klog.InfoS("Remove abandoned partial download", "path", path)
+		_ = os.Remove(path)
+		_ = os.Remove(path + validatorSuffix)
+	}
+}
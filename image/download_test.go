@@ -0,0 +1,179 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPullResume(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	var mu sync.Mutex
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/demo" {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		mu.Unlock()
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "demo", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"), filepath.Join(dir, "images.json"))
+	src := newTestSource(t, server.URL)
+	partial := store.partialPath(src, "app/demo")
+	if err := os.MkdirAll(filepath.Dir(partial), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(partial, content[:4000], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(partial+validatorSuffix, []byte(`"v1"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	digest, err := store.Pull(context.Background(), "app/demo", "", []*Source{src}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+	if digest != "sha256:"+hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected digest %s", digest)
+	}
+	mu.Lock()
+	if len(ranges) != 1 || ranges[0] != "bytes=4000-" {
+		t.Fatalf("download is not resumed, ranges: %q", ranges)
+	}
+	mu.Unlock()
+
+	// A partial file of another version of the artifact is dropped.
+	if err = ioutil.WriteFile(partial, []byte("old version"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(partial+validatorSuffix, []byte(`"v0"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.Pull(context.Background(), "app/demo", "", []*Source{src}, nil); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := ioutil.ReadFile(filepath.Join(dir, "images", "app/demo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(stored, content) {
+		t.Fatal("content of the restarted download mismatch")
+	}
+
+	// A complete partial file is answered with 416, the download starts over.
+	if err = ioutil.WriteFile(partial, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(partial+validatorSuffix, []byte(`"v1"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	ranges = nil
+	mu.Unlock()
+	if _, err = store.Pull(context.Background(), "app/demo", "", []*Source{src}, nil); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	if len(ranges) != 2 || ranges[0] != "bytes=10000-" || ranges[1] != "" {
+		t.Fatalf("download is not restarted, ranges: %q", ranges)
+	}
+	mu.Unlock()
+	if !src.Healthy() {
+		t.Fatal("source is marked unhealthy by a restarted download")
+	}
+}
+
+func TestPullShared(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/demo" {
+			http.NotFound(w, r)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		<-release
+		_, _ = w.Write([]byte("jar"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"), filepath.Join(dir, "images.json"))
+	src := newTestSource(t, server.URL)
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.Pull(context.Background(), "app/demo", "", []*Source{src}, nil)
+			errs <- err
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests := atomic.LoadInt32(&requests); requests != 1 {
+		t.Fatalf("expected one download, got %d", requests)
+	}
+}
+
+func TestPullCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/demo" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(bytes.Repeat([]byte("x"), 1024))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"), filepath.Join(dir, "images.json"))
+	src := newTestSource(t, server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := store.Pull(ctx, "app/demo", "", []*Source{src}, nil); err != context.DeadlineExceeded {
+		t.Fatalf("expected the deadline to abort the pull, got %v", err)
+	}
+	// The cancelled download lets go of the partial file, which is kept.
+	store.pullsMu.Lock()
+	p := store.pulls["app/demo"]
+	store.pullsMu.Unlock()
+	if p != nil {
+		<-p.done
+	}
+	partial, err := ioutil.ReadFile(store.partialPath(src, "app/demo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(partial) != 1024 {
+		t.Fatalf("expected the received bytes to be kept, got %d bytes", len(partial))
+	}
+}
@@ -0,0 +1,70 @@
+package image
+
+import (
+	"fmt"
+	"sort"
+)
+
+// GCPolicy tells when the unused images are garbage collected.
+type GCPolicy struct {
+	// Usage percent of the image filesystem which triggers the collection.
+	HighThresholdPercent int
+	// Usage percent of the image filesystem the collection frees down to.
+	LowThresholdPercent int
+}
+
+// Validate checks that the thresholds are percents and the low threshold is
+// not above the high one.
+func (p GCPolicy) Validate() error {
+	if p.HighThresholdPercent < 0 || p.HighThresholdPercent > 100 {
+		return fmt.Errorf("high threshold percent %d is out of the range [0, 100]", p.HighThresholdPercent)
+	}
+	if p.LowThresholdPercent < 0 || p.LowThresholdPercent > 100 {
+		return fmt.Errorf("low threshold percent %d is out of the range [0, 100]", p.LowThresholdPercent)
+	}
+	if p.LowThresholdPercent > p.HighThresholdPercent {
+		return fmt.Errorf("low threshold percent %d is above the high threshold percent %d",
+			p.LowThresholdPercent, p.HighThresholdPercent)
+	}
+	return nil
+}
+
+// BytesToFree returns how many bytes must be freed to bring the usage of the
+// filesystem down to the low threshold. It is zero while the usage is under
+// the high threshold, unless force is set.
+func (p GCPolicy) BytesToFree(capacity, available uint64, force bool) uint64 {
+	if capacity == 0 || available >= capacity {
+		return 0
+	}
+	usage := capacity - available
+	if !force && usage*100 < capacity*uint64(p.HighThresholdPercent) {
+		return 0
+	}
+	target := capacity * uint64(p.LowThresholdPercent) / 100
+	if usage <= target {
+		return 0
+	}
+	return usage - target
+}
+
+// SelectGC returns the images to remove to free bytesToFree bytes, least
+// recently used first. Images in inUse are never selected.
+func SelectGC(images []Image, inUse map[string]string, bytesToFree uint64) []Image {
+	var candidates []Image
+	for _, image := range images {
+		if _, ok := inUse[image.Key()]; !ok {
+			candidates = append(candidates, image)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LastUsedAt < candidates[j].LastUsedAt
+	})
+	var freed uint64
+	for i, image := range candidates {
+		if freed >= bytesToFree {
+			return candidates[:i]
+		}
+		freed += uint64(image.Size)
+	}
+	return candidates
+}
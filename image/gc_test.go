@@ -0,0 +1,56 @@
+package image
+
+import "testing"
+
+func TestBytesToFree(t *testing.T) {
+	policy := GCPolicy{HighThresholdPercent: 85, LowThresholdPercent: 80}
+	for _, c := range []struct {
+		available uint64
+		force     bool
+		expected  uint64
+	}{
+		{available: 200, expected: 0},
+		{available: 150, expected: 50},
+		{available: 100, expected: 100},
+		{available: 210, force: true, expected: 0},
+		{available: 170, force: true, expected: 30},
+	} {
+		if actual := policy.BytesToFree(1000, c.available, c.force); actual != c.expected {
+			t.Errorf("available %d, force %v: expected %d bytes to free, got %d", c.available, c.force, c.expected, actual)
+		}
+	}
+}
+
+func TestGCPolicyValidate(t *testing.T) {
+	for _, c := range []struct {
+		policy GCPolicy
+		valid  bool
+	}{
+		{GCPolicy{HighThresholdPercent: 85, LowThresholdPercent: 80}, true},
+		{GCPolicy{HighThresholdPercent: 100, LowThresholdPercent: 100}, true},
+		{GCPolicy{HighThresholdPercent: 80, LowThresholdPercent: 85}, false},
+		{GCPolicy{HighThresholdPercent: 120, LowThresholdPercent: 80}, false},
+		{GCPolicy{HighThresholdPercent: 85, LowThresholdPercent: -1}, false},
+	} {
+		if err := c.policy.Validate(); (err == nil) != c.valid {
+			t.Errorf("policy %+v: expected valid %t, err: %v", c.policy, c.valid, err)
+		}
+	}
+}
+
+func TestSelectGC(t *testing.T) {
+	images := []Image{
+		{Name: "a", Tag: "1", Size: 10, LastUsedAt: 3},
+		{Name: "b", Tag: "1", Size: 10, LastUsedAt: 1},
+		{Name: "c", Tag: "1", Size: 10, LastUsedAt: 2},
+		{Name: "d", Tag: "1", Size: 10, LastUsedAt: 0},
+	}
+	inUse := map[string]string{"d:1": "container"}
+	selected := SelectGC(images, inUse, 15)
+	if len(selected) != 2 || selected[0].Name != "b" || selected[1].Name != "c" {
+		t.Fatalf("unexpected images selected: %+v", selected)
+	}
+	if selected = SelectGC(images, inUse, 100); len(selected) != 3 {
+		t.Fatalf("expected all the unused images, got %+v", selected)
+	}
+}
@@ -0,0 +1,234 @@
+package image
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"k8s.io/klog/v2"
+	util "sobey-runtime/utils"
+)
+
+// Image is the metadata of an image in the store.
+type Image struct {
+	// Name of the image without the tag, like "app/demo".
+	Name string `json:"name"`
+	Tag  string `json:"tag"`
+	// Digest of the content, like "sha256:<hex>".
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+	// Unix nano time the image was pulled at.
+	PulledAt int64 `json:"pulledAt"`
+	// Unix nano time a container was last created from the image.
+	LastUsedAt int64 `json:"lastUsedAt"`
+	// Name of the image source the image was pulled from.
+	Source string `json:"source,omitempty"`
+	// Checksum published by the source the content matched, like
+	// "sha1:<hex>", empty when it was not verified.
+	VerifiedChecksum string `json:"verifiedChecksum,omitempty"`
+	// Name of the keyring file of the key which signed the content, empty
+	// when the signature was not verified.
+	SignedBy string `json:"signedBy,omitempty"`
+}
+
+// Key returns the name of the image file in the store, the "latest" tag is
+// left out like in CreateContainer.
+func (i *Image) Key() string {
+	if i.Tag == "" || i.Tag == util.DefaultImageTag {
+		return i.Name
+	}
+	return i.Name + ":" + i.Tag
+}
+
+// splitTag splits the image name of a file in the store into name and tag.
+func splitTag(key string) (string, string) {
+	ref, err := util.ParseImageReference(key)
+	if err != nil {
+		return key, util.DefaultImageTag
+	}
+	return ref.Name(), ref.Tag
+}
+
+// loadIndex reads the index file, it is rebuilt from the images in the store
+// when it is missing or corrupt.
+func (s *Store) loadIndex() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := ioutil.ReadFile(s.indexPath)
+	if err == nil {
+		images := make(map[string]*Image)
+		if err = json.Unmarshal(data, &images); err == nil && images != nil {
+			s.images = images
+			s.pruneIndex()
+			return
+		}
+	}
+	if !os.IsNotExist(err) {
+		klog.ErrorS(err, "Image index is corrupt, rebuild it", "path", s.indexPath)
+	}
+	s.images, err = scanImages(s.root)
+	if err != nil {
+		klog.ErrorS(err, "Failed to scan the image store", "path", s.root)
+		return
+	}
+	if err = s.saveIndex(); err != nil {
+		klog.ErrorS(err, "Failed to save the image index", "path", s.indexPath)
+	}
+}
+
+// pruneIndex drops the images whose file is gone.
+func (s *Store) pruneIndex() {
+	for key := range s.images {
+		path, err := s.Path(key)
+		if err == nil {
+			_, err = os.Stat(path)
+		}
+		if err != nil {
+			klog.InfoS("Drop the image missing from the store", "image", key)
+			delete(s.images, key)
+		}
+	}
+}
+
+// saveIndex writes the index file, it must be called with the lock held.
+func (s *Store) saveIndex() error {
+	data, err := json.Marshal(s.images)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(s.indexPath), 0750); err != nil {
+		return err
+	}
+	tmp := s.indexPath + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.indexPath)
+}
+
+// scanImages builds the index from the files in the image store.
+func scanImages(root string) (map[string]*Image, error) {
+	images := make(map[string]*Image)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		key, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		digest, err := fileDigest(path)
+		if err != nil {
+			return err
+		}
+		name, tag := splitTag(key)
+		images[key] = &Image{
+			Name:       name,
+			Tag:        tag,
+			Digest:     digest,
+			Size:       info.Size(),
+			PulledAt:   info.ModTime().UnixNano(),
+			LastUsedAt: info.ModTime().UnixNano(),
+		}
+		return nil
+	})
+	return images, err
+}
+
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// add records a pulled image in the index, image holds the digest, the size
+// and how the content was verified.
+func (s *Store) add(key string, image *Image) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	image.Name, image.Tag = splitTag(key)
+	image.PulledAt = time.Now().UnixNano()
+	image.LastUsedAt = image.PulledAt
+	s.images[key] = image
+	return s.saveIndex()
+}
+
+// List returns the images in the store sorted by name.
+func (s *Store) List() []Image {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	images := make([]Image, 0, len(s.images))
+	for _, image := range s.images {
+		images = append(images, *image)
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Key() < images[j].Key()
+	})
+	return images
+}
+
+// Get returns the image stored under the name, or with the digest.
+func (s *Store) Get(name string) (*Image, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if image, ok := s.images[name]; ok {
+		result := *image
+		return &result, true
+	}
+	for _, image := range s.images {
+		if image.Digest == name {
+			result := *image
+			return &result, true
+		}
+	}
+	return nil, false
+}
+
+// Touch marks the image as used now.
+func (s *Store) Touch(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	image, ok := s.images[name]
+	if !ok {
+		return nil
+	}
+	image.LastUsedAt = time.Now().UnixNano()
+	return s.saveIndex()
+}
+
+// Remove deletes the image file from the store and drops it from the index.
+func (s *Store) Remove(name string) error {
+	path, err := s.Path(name)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if _, ok := s.images[name]; !ok {
+		return nil
+	}
+	delete(s.images, name)
+	return s.saveIndex()
+}
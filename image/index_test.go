@@ -0,0 +1,58 @@
+package image
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIndexRebuild(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "images")
+	index := filepath.Join(dir, "images.json")
+	if err := os.MkdirAll(filepath.Join(root, "app"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"app/demo", "app/demo:1.0"} {
+		if err := ioutil.WriteFile(filepath.Join(root, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(index, []byte("{corrupt"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	images := NewStore(root, filepath.Join(dir, "tmp"), index).List()
+	if len(images) != 2 {
+		t.Fatalf("expected 2 images, got %+v", images)
+	}
+	if images[0].Name != "app/demo" || images[0].Tag != "latest" || images[1].Tag != "1.0" {
+		t.Fatalf("unexpected images %+v", images)
+	}
+	if images[0].Digest == images[1].Digest || images[0].Size != int64(len("app/demo")) {
+		t.Fatalf("unexpected images %+v", images)
+	}
+
+	// The rebuilt index is saved and drops the images removed from the store.
+	if err := os.Remove(filepath.Join(root, "app/demo")); err != nil {
+		t.Fatal(err)
+	}
+	store := NewStore(root, filepath.Join(dir, "tmp"), index)
+	if _, ok := store.Get("app/demo"); ok {
+		t.Fatal("removed image is still indexed")
+	}
+	if _, ok := store.Get(images[1].Digest); !ok {
+		t.Fatal("image is not found by digest")
+	}
+
+	if err := store.Remove("app/demo:1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "app/demo:1.0")); !os.IsNotExist(err) {
+		t.Fatalf("image file is not removed, err: %v", err)
+	}
+	if images = NewStore(root, filepath.Join(dir, "tmp"), index).List(); len(images) != 0 {
+		t.Fatalf("removed image is still indexed: %+v", images)
+	}
+}
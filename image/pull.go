@@ -0,0 +1,278 @@
+package image
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// checksumSuffixes are the checksum files Nexus publishes next to every
+// artifact, strongest first.
+var checksumSuffixes = []string{".sha256", ".sha1"}
+
+// pull is a download of an image shared by the concurrent pulls of it.
+type pull struct {
+	done   chan struct{}
+	digest string
+	err    error
+	// Number of callers waiting for the pull, the download is cancelled
+	// once all of them gave up.
+	waiters   int
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// Pull downloads the image from the first source which has it into the
+// store and returns the digest of its content, like "sha256:<hex>". The
+// download goes to the temp directory first and is only renamed into the
+// store once its checksum matches the one published by the source. The
+// requests are authenticated with auth when it is not nil, otherwise with
+// the credentials of the source. When digest is not empty the download is
+// discarded unless its content has that digest, the stored image is kept.
+//
+// Concurrent pulls of the same image share one download, made with the
+// sources, the credential and the digest of the first pull. The download stops when
+// ctx is done for all of them, and the next pull resumes it.
+func (s *Store) Pull(ctx context.Context, name, digest string, sources []*Source, auth *Credential) (string, error) {
+	for {
+		s.pullsMu.Lock()
+		p, ok := s.pulls[name]
+		if ok && p.cancelled {
+			// Wait for the cancelled download to let go of the partial file.
+			s.pullsMu.Unlock()
+			select {
+			case <-p.done:
+				continue
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+		if !ok {
+			pullCtx, cancel := context.WithCancel(context.Background())
+			p = &pull{done: make(chan struct{}), cancel: cancel}
+			s.pulls[name] = p
+			go func() {
+				p.digest, p.err = s.pull(pullCtx, name, digest, sources, auth)
+				cancel()
+				s.pullsMu.Lock()
+				delete(s.pulls, name)
+				s.pullsMu.Unlock()
+				close(p.done)
+			}()
+		}
+		p.waiters++
+		s.pullsMu.Unlock()
+
+		select {
+		case <-p.done:
+			if p.err == nil && digest != "" && p.digest != digest {
+				return "", fmt.Errorf("digest of %s mismatch, expected %s, got %s", name, digest, p.digest)
+			}
+			return p.digest, p.err
+		case <-ctx.Done():
+			s.pullsMu.Lock()
+			p.waiters--
+			if p.waiters == 0 {
+				p.cancelled = true
+				p.cancel()
+			}
+			s.pullsMu.Unlock()
+			return "", ctx.Err()
+		}
+	}
+}
+
+// pull tries the sources in order until one of them has the image.
+func (s *Store) pull(ctx context.Context, name, digest string, sources []*Source, auth *Credential) (string, error) {
+	if len(sources) == 0 {
+		return "", fmt.Errorf("no image source is configured for %s", name)
+	}
+	var errs []string
+	for _, src := range sources {
+		cred := auth
+		if cred == nil {
+			cred = src.credential(name)
+		}
+		ref, err := s.pullFrom(ctx, src, name, digest, cred)
+		if err == nil {
+			src.markSuccess()
+			return ref, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if !isNotFound(err) {
+			src.markFailure(err)
+		}
+		klog.InfoS("Failed to pull image from source", "image", name, "source", src.Name, "err", err)
+		errs = append(errs, fmt.Sprintf("%s: %v", src.Name, err))
+	}
+	return "", fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+func (s *Store) pullFrom(ctx context.Context, src *Source, name, expectedDigest string, cred *Credential) (string, error) {
+	dest, err := s.Path(name)
+	if err != nil {
+		return "", err
+	}
+	var suffix, expected string
+	if src.Policy.checksum() != Skip {
+		suffix, expected, err = src.fetchChecksum(ctx, name, cred)
+		if err != nil {
+			return "", err
+		}
+	}
+	if expected == "" {
+		if src.Policy.checksum() == Required {
+			return "", fmt.Errorf("image source %s publishes no checksum for %s, which its verify policy requires", src.Name, name)
+		}
+		klog.InfoS("Skip the checksum verification of the image", "image", name, "source", src.Name)
+	}
+	signature, err := src.fetchSignature(ctx, name, cred)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.New()
+	hashes := []hash.Hash{digest}
+	var verify hash.Hash
+	switch suffix {
+	case ".sha256":
+		verify = digest
+	case ".sha1":
+		verify = sha1.New()
+		hashes = append(hashes, verify)
+	}
+	d, err := s.openDownload(src, name, cred, hashes...)
+	if err != nil {
+		return "", err
+	}
+	if err = d.fetch(ctx); err != nil {
+		// The partial file is kept for the next pull to resume.
+		d.close()
+		return "", err
+	}
+	verified := &Image{Source: src.Name}
+	if verify != nil {
+		if actual := hex.EncodeToString(verify.Sum(nil)); actual != expected {
+			d.discard()
+			return "", fmt.Errorf("checksum of %s mismatch, expected %s%s, got %s", src.location(name), expected, suffix, actual)
+		}
+		verified.VerifiedChecksum = strings.TrimPrefix(suffix, ".") + ":" + expected
+	}
+	if signature != nil {
+		verified.SignedBy, err = src.Policy.Keyring.Verify(digest.Sum(nil), d.file.Name(), signature)
+		if err != nil {
+			d.discard()
+			return "", fmt.Errorf("verify signature of %s failed, err: %v", src.location(name), err)
+		}
+	}
+	ref := "sha256:" + hex.EncodeToString(digest.Sum(nil))
+	if expectedDigest != "" && ref != expectedDigest {
+		d.discard()
+		return "", fmt.Errorf("digest of %s mismatch, expected %s, got %s", src.location(name), expectedDigest, ref)
+	}
+	info, err := d.file.Stat()
+	if err == nil {
+		err = d.file.Sync()
+	}
+	if err != nil {
+		d.discard()
+		return "", err
+	}
+	if err = d.close(); err != nil {
+		d.discard()
+		return "", err
+	}
+	if err = os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+		return "", err
+	}
+	if err = os.Rename(d.file.Name(), dest); err != nil {
+		return "", err
+	}
+	_ = os.Remove(d.file.Name() + validatorSuffix)
+	verified.Digest = ref
+	verified.Size = info.Size()
+	if err = s.add(name, verified); err != nil {
+		return "", fmt.Errorf("update image index failed, err: %v", err)
+	}
+	return ref, nil
+}
+
+// fetchChecksum returns the suffix and the value of the first checksum file
+// the source publishes for the image, the value is empty when there is none.
+func (src *Source) fetchChecksum(ctx context.Context, name string, cred *Credential) (string, string, error) {
+	for _, suffix := range checksumSuffixes {
+		r, err := src.open(ctx, name+suffix, cred)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		sum, err := parseChecksum(r)
+		r.Close()
+		if err != nil {
+			return "", "", fmt.Errorf("read %s failed, err: %v", src.location(name+suffix), err)
+		}
+		return suffix, sum, nil
+	}
+	return "", "", nil
+}
+
+// fetchSignature returns the detached signature of the image when the
+// policy of the source verifies it, or nil.
+func (src *Source) fetchSignature(ctx context.Context, name string, cred *Credential) ([]byte, error) {
+	requirement := src.Policy.signature()
+	if requirement == Skip || (requirement == Optional && src.Policy.Keyring == nil) {
+		return nil, nil
+	}
+	if src.Policy.Keyring == nil {
+		return nil, fmt.Errorf("image source %s requires signatures without a keyring", src.Name)
+	}
+	r, err := src.open(ctx, name+signatureSuffix, cred)
+	if isNotFound(err) {
+		if requirement == Required {
+			return nil, fmt.Errorf("image source %s publishes no signature for %s, which its verify policy requires", src.Name, name)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	signature, err := ioutil.ReadAll(io.LimitReader(r, maxSignatureSize))
+	if err != nil {
+		return nil, fmt.Errorf("read %s failed, err: %v", src.location(name+signatureSuffix), err)
+	}
+	return signature, nil
+}
+
+// parseChecksum reads a checksum file, which holds the hex value optionally
+// followed by the file name like the output of sha256sum.
+func parseChecksum(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(io.LimitReader(r, 4096)).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("checksum is empty")
+	}
+	sum := strings.ToLower(fields[0])
+	if _, err = hex.DecodeString(sum); err != nil {
+		return "", fmt.Errorf("invalid checksum %q", fields[0])
+	}
+	return sum, nil
+}
@@ -0,0 +1,124 @@
+package image
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestSource(t *testing.T, url string) *Source {
+	src, err := NewSource("test", url, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func TestPull(t *testing.T) {
+	content := []byte("jar content")
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app/demo:1.0":
+			_, _ = w.Write(content)
+		case "/app/demo:1.0.sha256":
+			_, _ = w.Write([]byte(checksum + "  demo:1.0\n"))
+		case "/app/bad":
+			_, _ = w.Write(content)
+		case "/app/bad.sha256":
+			_, _ = w.Write([]byte("00" + checksum[2:]))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"), filepath.Join(dir, "images.json"))
+	src := newTestSource(t, server.URL)
+	digest, err := store.Pull(context.Background(), "app/demo:1.0", "", []*Source{src}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != "sha256:"+checksum {
+		t.Fatalf("unexpected digest %s", digest)
+	}
+	stored, err := ioutil.ReadFile(filepath.Join(dir, "images", "app/demo:1.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(stored) != string(content) {
+		t.Fatalf("unexpected content %q", stored)
+	}
+	img, ok := store.Get("app/demo:1.0")
+	if !ok || img.Name != "app/demo" || img.Tag != "1.0" || img.Digest != digest || img.Size != int64(len(content)) || img.VerifiedChecksum != "sha256:"+checksum {
+		t.Fatalf("unexpected index entry %+v", img)
+	}
+
+	if _, err = store.Pull(context.Background(), "app/demo:1.0", "sha256:0000", []*Source{src}, nil); err == nil {
+		t.Fatal("expected a digest mismatch")
+	}
+	if img, ok = store.Get("app/demo:1.0"); !ok || img.Digest != digest {
+		t.Fatalf("stored image is replaced by a mismatched pull, %+v", img)
+	}
+
+	if _, err = store.Pull(context.Background(), "app/bad", "", []*Source{src}, nil); err == nil {
+		t.Fatal("expected a checksum mismatch")
+	}
+	if _, err = os.Stat(filepath.Join(dir, "images", "app/bad")); !os.IsNotExist(err) {
+		t.Fatalf("image with a wrong checksum is stored, err: %v", err)
+	}
+	tmp, err := ioutil.ReadDir(filepath.Join(dir, "tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tmp) != 0 {
+		t.Fatalf("temp files are left behind: %d", len(tmp))
+	}
+	if _, err = store.Path("../etc/passwd"); err == nil {
+		t.Fatal("expected the image name to be rejected")
+	}
+}
+
+func TestPullWithCredential(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "deploy" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/private/demo" {
+			_, _ = w.Write([]byte("private jar"))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"), filepath.Join(dir, "images.json"))
+	src := newTestSource(t, server.URL)
+	if _, err := store.Pull(context.Background(), "private/demo", "", []*Source{src}, nil); err == nil {
+		t.Fatal("expected the anonymous pull to fail")
+	}
+	credentials := []RepoCredential{
+		{Prefix: server.URL + "/", Credential: Credential{Username: "other", Password: "other"}},
+		{Prefix: server.URL + "/private/", Credential: Credential{Username: "deploy", Password: "secret"}},
+	}
+	src.Credentials = credentials
+	if _, err := store.Pull(context.Background(), "private/demo", "", []*Source{src}, nil); err != nil {
+		t.Fatal(err)
+	}
+	cred := src.credential("private/demo")
+	if printed := fmt.Sprintf("%v %+v %#v", *cred, *cred, *cred); strings.Contains(printed, "secret") {
+		t.Fatalf("password is printed: %s", printed)
+	}
+}
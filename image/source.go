@@ -0,0 +1,255 @@
+package image
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// responseHeaderTimeout is how long to wait for a repository to answer.
+	responseHeaderTimeout = 30 * time.Second
+	// maxSourceFailures is how many pulls in a row may fail before a source
+	// is unhealthy.
+	maxSourceFailures = 3
+	// An unhealthy source is tried last until the backoff, doubled on every
+	// further failure, is over.
+	sourceBackoff    = 30 * time.Second
+	maxSourceBackoff = 10 * time.Minute
+)
+
+// Source is a repository the images are pulled from, a http(s) server like
+// Nexus or a local directory with a file:// URL for air-gapped sites.
+type Source struct {
+	Name string
+	// Base URL, the URL of an image is the base URL followed by its name.
+	URL string
+	// Prefixes of the names of the images pulled from the source, every
+	// image is when empty.
+	Prefixes []string
+	// Credentials of the repositories, used when the pull brings none.
+	Credentials []RepoCredential
+	// Policy tells how the content pulled from the source is verified.
+	Policy VerifyPolicy
+
+	client *http.Client
+	// Directory of a file:// source.
+	dir string
+
+	mu       sync.Mutex
+	failures int
+	retryAt  time.Time
+}
+
+// NewSource returns the source of the images under the base URL. The
+// certificates in caFile are trusted besides the system ones when it is set.
+func NewSource(name, baseURL, caFile string, prefixes []string) (*Source, error) {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse url of image source %s failed, err: %v", name, err)
+	}
+	src := &Source{Name: name, URL: baseURL, Prefixes: prefixes}
+	switch u.Scheme {
+	case "file":
+		src.dir = u.Path
+	case "http", "https":
+		src.client, err = newClient(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("create client of image source %s failed, err: %v", name, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported url scheme %q of image source %s", u.Scheme, name)
+	}
+	return src, nil
+}
+
+// newClient returns the client of a http(s) source. The whole transfer is
+// bound by the context of the pull, the timeout only catches a repository
+// which does not answer.
+func newClient(caFile string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = responseHeaderTimeout
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate is found in %s", caFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// Match tells whether the image is pulled from the source.
+func (src *Source) Match(name string) bool {
+	if len(src.Prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range src.Prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Healthy tells whether the last pulls from the source worked, or enough
+// time passed since they failed to try it again.
+func (src *Source) Healthy() bool {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	return src.failures < maxSourceFailures || time.Now().After(src.retryAt)
+}
+
+// SourceStatus is the health of an image source.
+type SourceStatus struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Healthy  bool   `json:"healthy"`
+	Failures int    `json:"failures"`
+}
+
+// Status returns the health of the source.
+func (src *Source) Status() SourceStatus {
+	healthy := src.Healthy()
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	return SourceStatus{Name: src.Name, URL: src.URL, Healthy: healthy, Failures: src.failures}
+}
+
+func (src *Source) markSuccess() {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	if src.failures >= maxSourceFailures {
+		klog.InfoS("Image source is healthy again", "source", src.Name)
+	}
+	src.failures = 0
+}
+
+func (src *Source) markFailure(err error) {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	src.failures++
+	if src.failures < maxSourceFailures {
+		return
+	}
+	backoff := sourceBackoff << uint(src.failures-maxSourceFailures)
+	if backoff > maxSourceBackoff || backoff <= 0 {
+		backoff = maxSourceBackoff
+	}
+	src.retryAt = time.Now().Add(backoff)
+	klog.ErrorS(err, "Image source is unhealthy", "source", src.Name, "failures", src.failures, "retryAfter", backoff)
+}
+
+// SelectSources returns the sources to pull the image from in order, the
+// unhealthy ones are kept as a last resort.
+func SelectSources(sources []*Source, name string) []*Source {
+	var healthy, unhealthy []*Source
+	for _, src := range sources {
+		if !src.Match(name) {
+			continue
+		}
+		if src.Healthy() {
+			healthy = append(healthy, src)
+		} else {
+			unhealthy = append(unhealthy, src)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// location returns the URL, or the path for a file:// source, of a file.
+func (src *Source) location(file string) string {
+	if src.dir != "" {
+		return filepath.Join(src.dir, file)
+	}
+	return src.URL + file
+}
+
+// credential returns the credential of the image in the source.
+func (src *Source) credential(name string) *Credential {
+	return MatchCredential(src.Credentials, src.URL+name)
+}
+
+// notFoundError tells that the source does not have a file, which is not a
+// failure of the source.
+type notFoundError struct {
+	location string
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("%s is not found", e.location)
+}
+
+func isNotFound(err error) bool {
+	_, ok := err.(*notFoundError)
+	return ok
+}
+
+// open returns the content of a file of the source.
+func (src *Source) open(ctx context.Context, file string, cred *Credential) (io.ReadCloser, error) {
+	location := src.location(file)
+	if src.dir != "" {
+		f, err := os.Open(location)
+		if os.IsNotExist(err) {
+			return nil, &notFoundError{location: location}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &contextReader{ctx: ctx, ReadCloser: f}, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	cred.apply(req)
+	resp, err := src.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, &notFoundError{location: location}
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("download %s failed, status: %s", location, resp.Status)
+	}
+}
+
+// contextReader stops reading a local file once the context is done.
+type contextReader struct {
+	ctx context.Context
+	io.ReadCloser
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.ReadCloser.Read(p)
+}
@@ -0,0 +1,53 @@
+package image
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPullMirrorFallback(t *testing.T) {
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+	mirror := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(mirror, "app"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(mirror, "app/demo"), []byte("jar"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	primary := newTestSource(t, broken.URL)
+	local, err := NewSource("local", "file://"+mirror, "", []string{"app/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := []*Source{primary, local}
+	if selected := SelectSources(sources, "other/demo"); len(selected) != 1 || selected[0] != primary {
+		t.Fatalf("unexpected sources selected by prefix: %v", selected)
+	}
+
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"), filepath.Join(dir, "images.json"))
+	for i := 0; i < maxSourceFailures; i++ {
+		if _, err = store.Pull(context.Background(), "app/demo", "", SelectSources(sources, "app/demo"), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if primary.Healthy() || !local.Healthy() {
+		t.Fatal("expected only the broken source to be unhealthy")
+	}
+	if selected := SelectSources(sources, "app/demo"); selected[0] != local || selected[1] != primary {
+		t.Fatal("expected the unhealthy source to be tried last")
+	}
+	img, ok := store.Get("app/demo")
+	if !ok || img.Source != "local" {
+		t.Fatalf("unexpected index entry %+v", img)
+	}
+}
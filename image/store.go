@@ -0,0 +1,53 @@
+package image
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Store is the socker image store, every image is a single file in the
+// images directory named after the image.
+type Store struct {
+	// Directory holding the images.
+	root string
+	// Directory holding the downloads in progress, on the same filesystem as
+	// root so the finished downloads can be renamed into the store.
+	tmp string
+
+	// Metadata of the images, keyed by the image file name.
+	mu        sync.Mutex
+	indexPath string
+	images    map[string]*Image
+
+	// Pulls in progress, keyed by the image file name.
+	pullsMu sync.Mutex
+	pulls   map[string]*pull
+}
+
+// NewStore returns the image store in root which downloads into tmp and keeps
+// the metadata of the images in the index file.
+func NewStore(root, tmp, index string) *Store {
+	s := &Store{
+		root:      root,
+		tmp:       tmp,
+		indexPath: index,
+		pulls:     make(map[string]*pull),
+	}
+	s.loadIndex()
+	s.prunePartials()
+	return s
+}
+
+// Path returns the path of the image file in the store.
+func (s *Store) Path(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("image name is empty")
+	}
+	path := filepath.Join(s.root, name)
+	if !strings.HasPrefix(path, filepath.Clean(s.root)+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid image name %q", name)
+	}
+	return path, nil
+}
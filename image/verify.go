@@ -0,0 +1,154 @@
+package image
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// signatureSuffix is the suffix of the detached signature published next to
+// an artifact.
+const signatureSuffix = ".sig"
+
+// maxSignatureSize bounds the size of a signature file.
+const maxSignatureSize = 64 * 1024
+
+// Requirement tells whether a verification of the pulled content must pass.
+type Requirement string
+
+const (
+	// Skip never runs the verification.
+	Skip Requirement = "none"
+	// Optional runs the verification when the source publishes what it
+	// needs, it is the default.
+	Optional Requirement = "optional"
+	// Required refuses the content the verification can not run for.
+	Required Requirement = "required"
+)
+
+// ParseRequirement parses a requirement of the config, empty is def.
+func ParseRequirement(s string, def Requirement) (Requirement, error) {
+	switch Requirement(s) {
+	case "":
+		return def, nil
+	case Skip, Optional, Required:
+		return Requirement(s), nil
+	}
+	return "", fmt.Errorf("unknown verification requirement %q, must be one of %s, %s and %s", s, Skip, Optional, Required)
+}
+
+// VerifyPolicy tells how the content pulled from a source is verified.
+type VerifyPolicy struct {
+	// Verification against the .sha256 or .sha1 file the source publishes.
+	Checksum Requirement
+	// Verification of the detached .sig signature against the keyring.
+	Signature Requirement
+	Keyring   *Keyring
+}
+
+func (p *VerifyPolicy) checksum() Requirement {
+	if p.Checksum == "" {
+		return Optional
+	}
+	return p.Checksum
+}
+
+func (p *VerifyPolicy) signature() Requirement {
+	if p.Signature == "" {
+		return Optional
+	}
+	return p.Signature
+}
+
+// Keyring holds the public keys the detached signatures are checked against.
+type Keyring struct {
+	// Public keys by the name of their file.
+	keys map[string]crypto.PublicKey
+}
+
+// LoadKeyring loads the PEM encoded public keys, RSA, ECDSA or Ed25519, of
+// the files in dir.
+func LoadKeyring(dir string) (*Keyring, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	keyring := &Keyring{keys: make(map[string]crypto.PublicKey)}
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		block, _ := pem.Decode(data)
+		if block == nil || block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse public key %s failed, err: %v", entry.Name(), err)
+		}
+		keyring.keys[entry.Name()] = key
+	}
+	if len(keyring.keys) == 0 {
+		return nil, fmt.Errorf("no public key is found in %s", dir)
+	}
+	return keyring, nil
+}
+
+// Verify checks the detached signature of the content, whose sha256 sum is
+// digest and which is read from path for Ed25519 keys. It returns the name
+// of the key which made the signature. Like openssl, RSA keys sign with
+// PKCS #1 v1.5 and ECDSA keys with ASN.1 signatures over the sha256 sum.
+func (k *Keyring) Verify(digest []byte, path string, signature []byte) (string, error) {
+	signature = decodeSignature(signature)
+	names := make([]string, 0, len(k.keys))
+	for name := range k.keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var content []byte
+	for _, name := range names {
+		switch key := k.keys[name].(type) {
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil {
+				return name, nil
+			}
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, digest, signature) {
+				return name, nil
+			}
+		case ed25519.PublicKey:
+			if content == nil {
+				var err error
+				if content, err = ioutil.ReadFile(path); err != nil {
+					return "", err
+				}
+			}
+			if ed25519.Verify(key, content, signature) {
+				return name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("signature is not made by any key of the keyring")
+}
+
+// decodeSignature accepts raw signatures and base64 encoded ones.
+func decodeSignature(signature []byte) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return signature
+	}
+	return decoded
+}
@@ -0,0 +1,101 @@
+package image
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePublicKey(t *testing.T, path string, key crypto.PublicKey) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPullVerifySignature(t *testing.T) {
+	content := []byte("signed jar")
+	sum := sha256.Sum256(content)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaSignature, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{
+		"/app/ecdsa":     content,
+		"/app/ecdsa.sig": ecdsaSignature,
+		"/app/ed25519":   content,
+		// Base64 encoded signatures are accepted too.
+		"/app/ed25519.sig": []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(edPrivate, content)) + "\n"),
+		"/app/forged":      content,
+		"/app/forged.sig":  ed25519.Sign(edPrivate, []byte("other jar")),
+		"/app/unsigned":    content,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	keyringDir := t.TempDir()
+	writePublicKey(t, filepath.Join(keyringDir, "ecdsa.pem"), &ecdsaKey.PublicKey)
+	writePublicKey(t, filepath.Join(keyringDir, "ed25519.pem"), edPublic)
+	keyring, err := LoadKeyring(keyringDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := newTestSource(t, server.URL)
+	src.Policy = VerifyPolicy{Checksum: Optional, Signature: Required, Keyring: keyring}
+
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, "images"), filepath.Join(dir, "tmp"), filepath.Join(dir, "images.json"))
+	for name, key := range map[string]string{"app/ecdsa": "ecdsa.pem", "app/ed25519": "ed25519.pem"} {
+		if _, err = store.Pull(context.Background(), name, "", []*Source{src}, nil); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if img, _ := store.Get(name); img.SignedBy != key {
+			t.Fatalf("%s: unexpected signer %q", name, img.SignedBy)
+		}
+	}
+	for _, name := range []string{"app/forged", "app/unsigned"} {
+		if _, err = store.Pull(context.Background(), name, "", []*Source{src}, nil); err == nil {
+			t.Fatalf("%s: expected the pull to be refused", name)
+		}
+		if _, err = os.Stat(filepath.Join(dir, "images", name)); !os.IsNotExist(err) {
+			t.Fatalf("%s: refused image is stored, err: %v", name, err)
+		}
+	}
+
+	// The checksum is required by default in the config.
+	src.Policy = VerifyPolicy{Checksum: Required}
+	if _, err = store.Pull(context.Background(), "app/unsigned", "", []*Source{src}, nil); err == nil {
+		t.Fatal("expected the image without checksum to be refused")
+	}
+}
@@ -3,14 +3,17 @@ package main
 import (
 	"fmt"
 	"google.golang.org/grpc"
+	runtimeapiv1 "k8s.io/cri-api/pkg/apis/runtime/v1"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 	kubeletconfiginternal "k8s.io/kubernetes/pkg/kubelet/apis/config"
 	"k8s.io/kubernetes/pkg/kubelet/dockershim"
 	"os"
 	"path/filepath"
+	sobeyapi "sobey-runtime/api/v1"
 	"sobey-runtime/common"
 	"sobey-runtime/config"
 	"sobey-runtime/etcd"
+	"sobey-runtime/monitor"
 	"sobey-runtime/src"
 	util "sobey-runtime/utils"
 )
@@ -19,9 +22,10 @@ const maxMsgSize = 1024 * 1024 * 16
 
 // SobeyServer ...
 type SobeyServer struct {
-	endpoint string
-	service  src.CRIService
-	server   *grpc.Server
+	endpoint  string
+	service   src.CRIService
+	v1Service src.V1CRIService
+	server    *grpc.Server
 }
 
 func init() {
@@ -57,6 +61,15 @@ func init() {
 }
 
 func main() {
+	// sobey-runtime re-executes itself to supervise every container.
+	if len(os.Args) > 1 && os.Args[1] == monitor.CommandName {
+		err := monitor.Run(os.Args[2:])
+		if err != nil {
+			fmt.Printf("Run container monitor err, err: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	err := config.InitConf()
 	if err != nil {
@@ -90,15 +103,38 @@ func main() {
 		fmt.Println("The config of server is not exist")
 		return
 	}
-	ss, _ := src.NewSobeyService(serverConf, &pluginSettings)
+	imageGCConf, err := config.InitImageGCConf()
+	if err != nil {
+		fmt.Printf("Init image gc config err, err: %v", err)
+		return
+	}
+	ss, err := src.NewSobeyService(&src.Options{
+		Server:       serverConf,
+		Streaming:    config.InitStreamingConf(),
+		ContainerLog: config.InitContainerLogConf(),
+		ImageGC:      imageGCConf,
+		Credentials:  config.InitCredentialsConf(),
+		ImageSources: config.InitImageSourcesConf(),
+		Prewarm:      config.InitPrewarmConf(),
+	}, &pluginSettings)
+	if err != nil {
+		fmt.Printf("Init sobey service err, err: %v", err)
+		return
+	}
 	err = ss.InitIpRange()
 	if err != nil {
 		fmt.Printf("Init ip range err, err: %v", err)
 		return
 	}
+	err = ss.Start()
+	if err != nil {
+		fmt.Printf("Start sobey service err, err: %v", err)
+		return
+	}
 	s := SobeyServer{
-		endpoint: "unix:///run/sobeyshim.sock",
-		service:  ss,
+		endpoint:  "unix:///run/sobeyshim.sock",
+		service:   ss,
+		v1Service: src.NewSobeyV1Service(ss),
 	}
 	l, err := util.CreateListener(s.endpoint)
 	if err != nil {
@@ -111,6 +147,9 @@ func main() {
 	)
 	runtimeapi.RegisterRuntimeServiceServer(s.server, s.service)
 	runtimeapi.RegisterImageServiceServer(s.server, s.service)
+	runtimeapiv1.RegisterRuntimeServiceServer(s.server, s.v1Service)
+	runtimeapiv1.RegisterImageServiceServer(s.server, s.v1Service)
+	sobeyapi.RegisterExtensionServiceServer(s.server, s.service)
 	err = s.server.Serve(l)
 	if err != nil {
 		fmt.Printf("Failed to serve connections, err: %v", err)
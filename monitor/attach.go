@@ -0,0 +1,156 @@
+package monitor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"sobey-runtime/common"
+	"sync"
+	"time"
+)
+
+const (
+	// Stream ids in the header of an attach frame.
+	stdoutFrame byte = 1
+	stderrFrame byte = 2
+
+	// An attach frame is a 1 byte stream id, a 4 bytes big endian payload
+	// length and the payload.
+	frameHeaderSize = 5
+
+	// Attach clients which can not take the output in time are dropped, so
+	// they never block the container.
+	attachWriteTimeout = time.Second
+)
+
+// attachServer fans the container output out to the clients connected to
+// the attach socket, and feeds their input to the container stdin.
+type attachServer struct {
+	listener  net.Listener
+	stdin     io.WriteCloser
+	stdinOnce bool
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+func newAttachServer(path string, stdin io.WriteCloser, stdinOnce bool) (*attachServer, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return &attachServer{
+		listener:  listener,
+		stdin:     stdin,
+		stdinOnce: stdinOnce,
+		conns:     make(map[net.Conn]struct{}),
+	}, nil
+}
+
+func (a *attachServer) serve() {
+	for {
+		conn, err := a.listener.Accept()
+		if err != nil {
+			return
+		}
+		a.mu.Lock()
+		a.conns[conn] = struct{}{}
+		a.mu.Unlock()
+		go a.handleInput(conn)
+	}
+}
+
+// handleInput copies the input of a client to the container stdin. The
+// connection stays registered for output until a write to it fails.
+func (a *attachServer) handleInput(conn net.Conn) {
+	if a.stdin == nil {
+		_, _ = io.Copy(ioutil.Discard, conn)
+		return
+	}
+	_, _ = io.Copy(a.stdin, conn)
+	if a.stdinOnce {
+		_ = a.stdin.Close()
+	}
+}
+
+func (a *attachServer) broadcast(stream byte, p []byte) {
+	frame := make([]byte, frameHeaderSize+len(p))
+	frame[0] = stream
+	binary.BigEndian.PutUint32(frame[1:frameHeaderSize], uint32(len(p)))
+	copy(frame[frameHeaderSize:], p)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for conn := range a.conns {
+		_ = conn.SetWriteDeadline(time.Now().Add(attachWriteTimeout))
+		if _, err := conn.Write(frame); err != nil {
+			_ = conn.Close()
+			delete(a.conns, conn)
+		}
+	}
+}
+
+func (a *attachServer) Close() error {
+	err := a.listener.Close()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for conn := range a.conns {
+		_ = conn.Close()
+		delete(a.conns, conn)
+	}
+	return err
+}
+
+// Attach connects to the monitor of a container. It copies stdin to the
+// container and the container output to stdout and stderr until the
+// container exits or the monitor goes away.
+func Attach(id string, stdin io.Reader, stdout, stderr io.Writer) error {
+	conn, err := net.Dial("unix", fmt.Sprintf(common.SockerContainerAttachHome, id))
+	if err != nil {
+		return fmt.Errorf("failed to connect to the monitor of container %s: %v", id, err)
+	}
+	defer conn.Close()
+
+	if stdin != nil {
+		go func() {
+			_, _ = io.Copy(conn, stdin)
+			if unixConn, ok := conn.(*net.UnixConn); ok {
+				_ = unixConn.CloseWrite()
+			}
+		}()
+	}
+	return readFrames(conn, stdout, stderr)
+}
+
+// readFrames demultiplexes the attach frames read from r.
+func readFrames(r io.Reader, stdout, stderr io.Writer) error {
+	if stdout == nil {
+		stdout = ioutil.Discard
+	}
+	if stderr == nil {
+		stderr = ioutil.Discard
+	}
+	header := make([]byte, frameHeaderSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		w := stdout
+		if header[0] == stderrFrame {
+			w = stderr
+		}
+		size := int64(binary.BigEndian.Uint32(header[1:]))
+		if _, err := io.CopyN(w, r, size); err != nil {
+			return err
+		}
+	}
+}
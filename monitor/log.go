@@ -0,0 +1,210 @@
+package monitor
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// Stream names used in the CRI log format.
+	stdoutStream = "stdout"
+	stderrStream = "stderr"
+
+	// Tags of the CRI log format, a line longer than maxLogLineSize is split
+	// into partial records.
+	partialTag = "P"
+	fullTag    = "F"
+
+	maxLogLineSize = 16 * 1024
+
+	// Suffix of the rotated log files.
+	rotatedLogTimeFormat = "20060102-150405.000000000"
+)
+
+// logFile is the container log file shared by the stdout and stderr streams.
+// When maxSize is set the file is rotated once it would grow beyond maxSize,
+// and at most maxFiles rotated files are kept next to it.
+type logFile struct {
+	mu       sync.Mutex
+	path     string
+	file     *os.File
+	size     int64
+	maxSize  int64
+	maxFiles int
+}
+
+func openLogFile(path string, maxSize int64, maxFiles int) (*logFile, error) {
+	l := &logFile{path: path, maxSize: maxSize, maxFiles: maxFiles}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *logFile) open() error {
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+func (l *logFile) writeRecord(record []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(record)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.file.Write(record)
+	l.size += int64(n)
+	return err
+}
+
+// rotate moves the current file aside and starts a new one, the caller holds
+// the lock.
+func (l *logFile) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	if _, err := RotateLogFile(l.path); err != nil {
+		// Keep writing to the current file rather than losing the output.
+		if openErr := l.open(); openErr != nil {
+			return openErr
+		}
+		return err
+	}
+	if err := l.open(); err != nil {
+		return err
+	}
+	return PruneRotatedLogs(l.path, l.maxFiles)
+}
+
+// reopen closes the file and opens the file at the path again, creating it
+// if it was moved away.
+func (l *logFile) reopen() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	return l.open()
+}
+
+func (l *logFile) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// RotateLogFile renames the log file at path to a rotated file next to it and
+// returns the path of the rotated file. The writer keeps writing to the
+// rotated file until it reopens the path.
+func RotateLogFile(path string) (string, error) {
+	rotated := fmt.Sprintf("%s.%s", path, time.Now().UTC().Format(rotatedLogTimeFormat))
+	if err := os.Rename(path, rotated); err != nil {
+		return "", err
+	}
+	return rotated, nil
+}
+
+// RotatedLogs returns the rotated files of the log file at path, oldest first.
+func RotatedLogs(path string) ([]string, error) {
+	rotated, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, err
+	}
+	// The timestamp suffix has a fixed width, so the names sort by age.
+	sort.Strings(rotated)
+	return rotated, nil
+}
+
+// PruneRotatedLogs removes the oldest rotated files of the log file at path
+// until at most maxFiles are left, zero keeps all of them.
+func PruneRotatedLogs(path string, maxFiles int) error {
+	if maxFiles <= 0 {
+		return nil
+	}
+	rotated, err := RotatedLogs(path)
+	if err != nil {
+		return err
+	}
+	for len(rotated) > maxFiles {
+		if err = os.Remove(rotated[0]); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		rotated = rotated[1:]
+	}
+	return nil
+}
+
+// criLogWriter splits the output of one stream into lines and writes them to
+// the log file in the CRI log format:
+// "<RFC3339Nano timestamp> <stream> <P|F> <content>\n".
+type criLogWriter struct {
+	stream string
+	log    *logFile
+	buf    []byte
+	now    func() time.Time
+}
+
+func newCRILogWriter(stream string, log *logFile) *criLogWriter {
+	return &criLogWriter{stream: stream, log: log, now: time.Now}
+}
+
+func (w *criLogWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		if err := w.writeLine(w.buf[:idx], fullTag); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[idx+1:]
+	}
+	for len(w.buf) >= maxLogLineSize {
+		if err := w.writeLine(w.buf[:maxLogLineSize], partialTag); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[maxLogLineSize:]
+	}
+	return len(p), nil
+}
+
+// Flush writes out the pending content which has no trailing newline.
+func (w *criLogWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLine(w.buf, partialTag)
+	w.buf = nil
+	return err
+}
+
+func (w *criLogWriter) writeLine(line []byte, tag string) error {
+	record := make([]byte, 0, len(line)+64)
+	record = append(record, w.now().UTC().Format(time.RFC3339Nano)...)
+	record = append(record, ' ')
+	record = append(record, w.stream...)
+	record = append(record, ' ')
+	record = append(record, tag...)
+	record = append(record, ' ')
+	record = append(record, line...)
+	record = append(record, '\n')
+	return w.log.writeRecord(record)
+}
@@ -0,0 +1,381 @@
+// Package monitor implements the process which supervises one socker
+// container. It owns the stdio pipes of the container, writes its output to
+// the container log file, serves attach sessions and records the exit status
+// once the container is gone. The monitor runs detached from sobey-runtime,
+// so containers and their exit status survive restarts of the runtime.
+package monitor
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sobey-runtime/common"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// CommandName is the argument sobey-runtime is re-executed with to run a monitor.
+	CommandName = "monitor"
+
+	// How long the monitor keeps draining the output pipes after socker exited.
+	drainTimeout = 2 * time.Second
+
+	// The signal which makes the monitor reopen the log file.
+	reopenLogSignal = syscall.SIGUSR1
+
+	// How long ReopenLog waits for the monitor to reopen the log file.
+	reopenLogTimeout = 5 * time.Second
+)
+
+// Options describe the container a monitor supervises.
+type Options struct {
+	ID        string
+	LogPath   string
+	Stdin     bool
+	StdinOnce bool
+	// Size in bytes at which the log file is rotated, zero disables the
+	// rotation.
+	LogMaxSize int64
+	// Number of rotated log files to keep, zero keeps all of them.
+	LogMaxFiles int
+}
+
+func (o Options) args() []string {
+	return []string{
+		CommandName,
+		"--id", o.ID,
+		"--log-path", o.LogPath,
+		fmt.Sprintf("--stdin=%t", o.Stdin),
+		fmt.Sprintf("--stdin-once=%t", o.StdinOnce),
+		fmt.Sprintf("--log-max-size=%d", o.LogMaxSize),
+		fmt.Sprintf("--log-max-files=%d", o.LogMaxFiles),
+	}
+}
+
+// ExitStatus is what the monitor records when the container exits.
+type ExitStatus struct {
+	ExitCode int32  `json:"exitCode"`
+	ExitedAt int64  `json:"exitedAt"`
+	Signal   string `json:"signal"`
+	// OOMKilled is set when the OOM killer killed a process of the container.
+	OOMKilled bool `json:"oomKilled"`
+}
+
+// Start launches a detached monitor for the container by re-executing the
+// current binary.
+func Start(opts Options) error {
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	command := exec.Command(self, opts.args()...)
+	command.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	monitorLog, err := os.OpenFile(fmt.Sprintf(common.SockerContainerMonitorLogHome, opts.ID),
+		os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	defer monitorLog.Close()
+	command.Stdout = monitorLog
+	command.Stderr = monitorLog
+	if err = command.Start(); err != nil {
+		return err
+	}
+	// Reap the monitor if it exits while sobey-runtime is still running.
+	go func() {
+		_ = command.Wait()
+	}()
+	return nil
+}
+
+// Run is the entrypoint of the monitor process, args are the arguments
+// following CommandName.
+func Run(args []string) error {
+	opts := Options{}
+	flags := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flags.StringVar(&opts.ID, "id", "", "id of the container to run")
+	flags.StringVar(&opts.LogPath, "log-path", "", "path of the container log file")
+	flags.BoolVar(&opts.Stdin, "stdin", false, "keep the stdin of the container open for attach")
+	flags.BoolVar(&opts.StdinOnce, "stdin-once", false, "close the stdin of the container after the first attach session")
+	flags.Int64Var(&opts.LogMaxSize, "log-max-size", 0, "size in bytes at which the log file is rotated, 0 disables the rotation")
+	flags.IntVar(&opts.LogMaxFiles, "log-max-files", 0, "number of rotated log files to keep, 0 keeps all of them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(opts.ID) == 0 || len(opts.LogPath) == 0 {
+		return fmt.Errorf("both --id and --log-path are required")
+	}
+	return run(opts)
+}
+
+func run(opts Options) error {
+	log, err := openLogFile(opts.LogPath, opts.LogMaxSize, opts.LogMaxFiles)
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+
+	err = ioutil.WriteFile(fmt.Sprintf(common.SockerContainerMonitorPidHome, opts.ID),
+		[]byte(strconv.Itoa(os.Getpid())), 0640)
+	if err != nil {
+		return err
+	}
+	reopen := make(chan os.Signal, 1)
+	signal.Notify(reopen, reopenLogSignal)
+	defer signal.Stop(reopen)
+	go func() {
+		for range reopen {
+			if err := log.reopen(); err != nil {
+				fmt.Printf("Reopen container log err, err: %v\n", err)
+			}
+		}
+	}()
+
+	command := exec.Command("socker", "run", opts.ID)
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	stderrReader, stderrWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	command.Stdout = stdoutWriter
+	command.Stderr = stderrWriter
+	var stdin io.WriteCloser
+	if opts.Stdin {
+		stdinReader, stdinWriter, err := os.Pipe()
+		if err != nil {
+			return err
+		}
+		command.Stdin = stdinReader
+		defer stdinReader.Close()
+		stdin = stdinWriter
+	}
+
+	attach, err := newAttachServer(fmt.Sprintf(common.SockerContainerAttachHome, opts.ID), stdin, opts.StdinOnce)
+	if err != nil {
+		return err
+	}
+	defer attach.Close()
+	go attach.serve()
+
+	if err = command.Start(); err != nil {
+		_ = writeExitStatus(opts.ID, &ExitStatus{ExitCode: -1, ExitedAt: time.Now().UnixNano()})
+		return err
+	}
+	// Only the container keeps the write ends open, so the readers see EOF
+	// once it is gone.
+	_ = stdoutWriter.Close()
+	_ = stderrWriter.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		copyOutput(stdoutReader, stdoutFrame, newCRILogWriter(stdoutStream, log), attach)
+	}()
+	go func() {
+		defer wg.Done()
+		copyOutput(stderrReader, stderrFrame, newCRILogWriter(stderrStream, log), attach)
+	}()
+
+	exited := make(chan struct{})
+	oom := new(oomWatcher)
+	go oom.watch(opts.ID, exited)
+
+	waitErr := command.Wait()
+	close(exited)
+	exitStatus := toExitStatus(command.ProcessState)
+	if waitErr != nil && command.ProcessState == nil {
+		exitStatus.ExitCode = -1
+	}
+	exitStatus.OOMKilled = oom.oomKilled()
+
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(drainTimeout):
+		fmt.Printf("Output of container %s is still open after socker exited\n", opts.ID)
+	}
+	return writeExitStatus(opts.ID, exitStatus)
+}
+
+// copyOutput copies one output stream of the container to the log file and
+// the attach clients.
+func copyOutput(r io.Reader, stream byte, log *criLogWriter, attach *attachServer) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			attach.broadcast(stream, buf[:n])
+			if _, werr := log.Write(buf[:n]); werr != nil {
+				fmt.Printf("Write container log err, err: %v\n", werr)
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err := log.Flush(); err != nil {
+		fmt.Printf("Write container log err, err: %v\n", err)
+	}
+}
+
+func toExitStatus(state *os.ProcessState) *ExitStatus {
+	exitStatus := &ExitStatus{ExitedAt: time.Now().UnixNano()}
+	if state == nil {
+		return exitStatus
+	}
+	waitStatus, ok := state.Sys().(syscall.WaitStatus)
+	if ok && waitStatus.Signaled() {
+		exitStatus.Signal = waitStatus.Signal().String()
+		exitStatus.ExitCode = 128 + int32(waitStatus.Signal())
+		return exitStatus
+	}
+	exitStatus.ExitCode = int32(state.ExitCode())
+	return exitStatus
+}
+
+// writeExitStatus stores the exit status atomically, readers never see a
+// partially written file.
+func writeExitStatus(id string, exitStatus *ExitStatus) error {
+	bytes, err := json.Marshal(exitStatus)
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf(common.SockerContainerExitHome, id)
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), ".exit")
+	if err != nil {
+		return err
+	}
+	if _, err = tmpFile.Write(bytes); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
+
+// ReopenLog makes the monitor of the container reopen the container log file
+// at path, and waits until the monitor holds the file at path open.
+func ReopenLog(id, path string) error {
+	pid, err := monitorPid(id)
+	if err != nil {
+		return err
+	}
+	if err = syscall.Kill(pid, reopenLogSignal); err != nil {
+		return fmt.Errorf("failed to signal the monitor of container %s: %v", id, err)
+	}
+	deadline := time.Now().Add(reopenLogTimeout)
+	for {
+		reopened, err := holdsFile(pid, path)
+		if err != nil {
+			return fmt.Errorf("failed to check the log file of the monitor of container %s: %v", id, err)
+		}
+		if reopened {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("monitor of container %s did not reopen %s in %v", id, path, reopenLogTimeout)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// monitorPid returns the pid recorded by the monitor of the container, after
+// checking the process is still that monitor and not a process reusing the
+// pid.
+func monitorPid(id string) (int, error) {
+	bytes, err := ioutil.ReadFile(fmt.Sprintf(common.SockerContainerMonitorPidHome, id))
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(bytes)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid pid of the monitor of container %s: %v", id, err)
+	}
+	cmdline, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if err != nil || !isMonitorOf(strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00"), id) {
+		return 0, fmt.Errorf("monitor of container %s is not running", id)
+	}
+	return pid, nil
+}
+
+// isMonitorOf tells whether args are the arguments of the monitor of the
+// container.
+func isMonitorOf(args []string, id string) bool {
+	if len(args) < 2 || args[1] != CommandName {
+		return false
+	}
+	for i := 2; i+1 < len(args); i++ {
+		if args[i] == "--id" && args[i+1] == id {
+			return true
+		}
+	}
+	return false
+}
+
+// holdsFile tells whether the process has the file at path open.
+func holdsFile(pid int, path string) (bool, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	fdDir := fmt.Sprintf("/proc/%d/fd", pid)
+	dir, err := os.Open(fdDir)
+	if err != nil {
+		return false, err
+	}
+	fds, err := dir.Readdirnames(-1)
+	_ = dir.Close()
+	if err != nil {
+		return false, err
+	}
+	for _, fd := range fds {
+		fdInfo, err := os.Stat(filepath.Join(fdDir, fd))
+		if err == nil && os.SameFile(info, fdInfo) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ReadExitStatus returns the exit status recorded by the monitor of the
+// container, the error satisfies os.IsNotExist while the container runs.
+func ReadExitStatus(id string) (*ExitStatus, error) {
+	bytes, err := ioutil.ReadFile(fmt.Sprintf(common.SockerContainerExitHome, id))
+	if err != nil {
+		return nil, err
+	}
+	exitStatus := new(ExitStatus)
+	err = json.Unmarshal(bytes, &exitStatus)
+	if err != nil {
+		return nil, err
+	}
+	return exitStatus, nil
+}
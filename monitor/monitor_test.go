@@ -0,0 +1,156 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCRILogWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "0.log")
+	log, err := openLogFile(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2022, 7, 1, 8, 0, 0, 0, time.UTC)
+	writer := &criLogWriter{stream: stdoutStream, log: log, now: func() time.Time { return ts }}
+	for _, p := range []string{"hello ", "world\nsecond", " line\n", "tail"} {
+		if _, err = writer.Write([]byte(p)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = writer.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	_ = log.Close()
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := ts.Format(time.RFC3339Nano) + " stdout "
+	expected := strings.Join([]string{
+		prefix + "F hello world",
+		prefix + "F second line",
+		prefix + "P tail",
+	}, "\n") + "\n"
+	if string(content) != expected {
+		t.Fatalf("unexpected log content:\n%s\nexpected:\n%s", content, expected)
+	}
+}
+
+func TestReadFrames(t *testing.T) {
+	frames := new(bytes.Buffer)
+	for _, frame := range []struct {
+		stream  byte
+		payload string
+	}{
+		{stdoutFrame, "out1"},
+		{stderrFrame, "err"},
+		{stdoutFrame, "out2"},
+	} {
+		header := make([]byte, frameHeaderSize)
+		header[0] = frame.stream
+		binary.BigEndian.PutUint32(header[1:], uint32(len(frame.payload)))
+		frames.Write(header)
+		frames.WriteString(frame.payload)
+	}
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	if err := readFrames(frames, stdout, stderr); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.String() != "out1out2" || stderr.String() != "err" {
+		t.Fatalf("unexpected output, stdout: %q, stderr: %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestLogFileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "0.log")
+	log, err := openLogFile(path, 100, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer log.Close()
+	record := []byte(strings.Repeat("x", 59) + "\n")
+	for i := 0; i < 5; i++ {
+		if err = log.writeRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rotated, err := RotatedLogs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rotated) != 2 {
+		t.Fatalf("expected 2 rotated files, got %v", rotated)
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, record) {
+		t.Fatalf("unexpected content of the current file: %q", content)
+	}
+}
+
+func TestTailLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "0.log")
+	log, err := openLogFile(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := newCRILogWriter(stderrStream, log)
+	if _, err = writer.Write([]byte("line 1\nline 2\n" + strings.Repeat("y", maxLogLineSize+10) + "\nline 4\n")); err != nil {
+		t.Fatal(err)
+	}
+	_ = log.Close()
+
+	tail, err := TailLog(path, 2, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := strings.Repeat("y", 1024-len("\nline 4")) + "\nline 4"; tail != expected {
+		t.Fatalf("unexpected tail %q", tail)
+	}
+	tail, err = TailLog(path, 3, 1024*1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(tail, "line 2\nyyy") {
+		t.Fatalf("the partial records are not joined, got %q", tail[:20])
+	}
+}
+
+func TestMonitorProcess(t *testing.T) {
+	args := Options{ID: "abc", LogPath: "/var/log/abc.log"}.args()
+	if !isMonitorOf(append([]string{"/usr/bin/sobey-runtime"}, args...), "abc") {
+		t.Fatal("expected the monitor of abc")
+	}
+	for _, args := range [][]string{
+		append([]string{"/usr/bin/sobey-runtime"}, Options{ID: "abcd", LogPath: "/var/log/abc.log"}.args()...),
+		{"/bin/sleep", "--id", "abc"},
+		{"/bin/sh"},
+	} {
+		if isMonitorOf(args, "abc") {
+			t.Fatalf("unexpected monitor of abc: %v", args)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "0.log")
+	if held, err := holdsFile(os.Getpid(), path); err != nil || held {
+		t.Fatalf("missing file is held, err: %v", err)
+	}
+	log, err := openLogFile(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer log.Close()
+	if held, err := holdsFile(os.Getpid(), path); err != nil || !held {
+		t.Fatalf("open file is not held, err: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sobey-runtime/cgroups"
+	"sobey-runtime/common"
+	"strings"
+	"sync"
+	"time"
+)
+
+const pidPollInterval = 100 * time.Millisecond
+
+// oomWatcher loads the cgroup of the container once socker recorded its pid,
+// so the OOM kills can still be counted after the container process is gone.
+type oomWatcher struct {
+	mu     sync.Mutex
+	cgroup *cgroups.Cgroup
+}
+
+// watch waits for the pid of the container until done is closed.
+func (w *oomWatcher) watch(id string, done <-chan struct{}) {
+	ticker := time.NewTicker(pidPollInterval)
+	defer ticker.Stop()
+	for {
+		bytes, err := ioutil.ReadFile(fmt.Sprintf(common.SockerContainerPidHome, id))
+		if pid := strings.TrimSpace(string(bytes)); err == nil && len(pid) != 0 {
+			cgroup, err := cgroups.Load(pid)
+			if err != nil {
+				fmt.Printf("Load cgroup of container %s err, err: %v\n", id, err)
+				return
+			}
+			w.mu.Lock()
+			w.cgroup = cgroup
+			w.mu.Unlock()
+			return
+		}
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *oomWatcher) oomKilled() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cgroup == nil {
+		return false
+	}
+	count, err := w.cgroup.OOMKillCount()
+	if err != nil {
+		fmt.Printf("Read OOM kills of the container err, err: %v\n", err)
+		return false
+	}
+	return count > 0
+}
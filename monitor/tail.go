@@ -0,0 +1,64 @@
+package monitor
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// tailReadSize is how much of the end of a log file TailLog looks at.
+const tailReadSize = 64 * 1024
+
+// TailLog returns the content of the last maxLines lines of the CRI format
+// log file at path, cut to its last maxBytes bytes.
+func TailLog(path string, maxLines, maxBytes int) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	offset := info.Size() - tailReadSize
+	if offset < 0 {
+		offset = 0
+	}
+	data := make([]byte, info.Size()-offset)
+	if _, err = f.ReadAt(data, offset); err != nil && err != io.EOF {
+		return "", err
+	}
+	// The first record is cut when the read does not start at the beginning.
+	if offset > 0 {
+		if idx := bytes.IndexByte(data, '\n'); idx >= 0 {
+			data = data[idx+1:]
+		}
+	}
+
+	var lines [][]byte
+	var partial []byte
+	for _, record := range bytes.Split(data, []byte{'\n'}) {
+		// "<timestamp> <stream> <P|F> <content>"
+		fields := bytes.SplitN(record, []byte{' '}, 4)
+		if len(fields) != 4 {
+			continue
+		}
+		partial = append(partial, fields[3]...)
+		if string(fields[2]) == fullTag {
+			lines = append(lines, partial)
+			partial = nil
+		}
+	}
+	if len(partial) != 0 {
+		lines = append(lines, partial)
+	}
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	content := bytes.Join(lines, []byte{'\n'})
+	if len(content) > maxBytes {
+		content = content[len(content)-maxBytes:]
+	}
+	return string(content), nil
+}
@@ -0,0 +1,85 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	"k8s.io/klog/v2"
+	"math"
+	sobeyapi "sobey-runtime/api/v1"
+	"sobey-runtime/common"
+	"sobey-runtime/image"
+	util "sobey-runtime/utils"
+	"time"
+)
+
+func (ss *sobeyService) GarbageCollectImages(ctx context.Context, req *sobeyapi.GarbageCollectImagesRequest) (*sobeyapi.GarbageCollectImagesResponse, error) {
+	return ss.garbageCollectImages(req.DryRun, true, req.All)
+}
+
+// garbageCollectImages removes the unused images least recently used first
+// until the usage of the image filesystem is under the low threshold. It does
+// nothing while the usage is under the high threshold unless force is set,
+// and all removes every unused image.
+func (ss *sobeyService) garbageCollectImages(dryRun, force, all bool) (*sobeyapi.GarbageCollectImagesResponse, error) {
+	ss.imageGCLock.Lock()
+	defer ss.imageGCLock.Unlock()
+	capacity, available, err := util.FsUsage(common.SockerImagesPath)
+	if err != nil {
+		return nil, fmt.Errorf("get usage of the image filesystem failed, err: %v", err)
+	}
+	resp := &sobeyapi.GarbageCollectImagesResponse{
+		CapacityBytes: capacity,
+		UsedBytes:     capacity - available,
+		BytesToFree:   ss.imageGCPolicy.BytesToFree(capacity, available, force),
+	}
+	bytesToFree := resp.BytesToFree
+	if all {
+		bytesToFree = math.MaxUint64
+	}
+	if bytesToFree == 0 {
+		return resp, nil
+	}
+	usedImages, err := ss.usedImages()
+	if err != nil {
+		return nil, err
+	}
+	// The pre-warmed images are kept even when no container uses them.
+	for _, name := range ss.prewarmer.listedImages() {
+		usedImages[ss.storeImageName(name)] = ""
+	}
+	for _, img := range image.SelectGC(ss.imageStore.List(), usedImages, bytesToFree) {
+		if !dryRun {
+			if err = ss.imageStore.Remove(img.Key()); err != nil {
+				klog.ErrorS(err, "Failed to remove image", "image", img.Key())
+				continue
+			}
+			resp.FreedBytes += uint64(img.Size)
+			klog.InfoS("Garbage collected image", "image", img.Key(), "digest", img.Digest, "size", img.Size)
+		}
+		resp.Images = append(resp.Images, &sobeyapi.CollectedImage{
+			Name:       img.Name,
+			Tag:        img.Tag,
+			Digest:     img.Digest,
+			SizeBytes:  uint64(img.Size),
+			LastUsedAt: img.LastUsedAt,
+		})
+	}
+	return resp, nil
+}
+
+// imageGCLoop checks the usage of the image filesystem every period and
+// collects the unused images once it is over the high threshold.
+func (ss *sobeyService) imageGCLoop() {
+	ticker := time.NewTicker(ss.imageGCPeriod)
+	defer ticker.Stop()
+	for range ticker.C {
+		resp, err := ss.garbageCollectImages(false, false, false)
+		if err != nil {
+			klog.ErrorS(err, "Image garbage collection failed")
+			continue
+		}
+		if resp.BytesToFree > 0 && resp.FreedBytes < resp.BytesToFree {
+			klog.InfoS("Image garbage collection freed less than required", "bytesToFree", resp.BytesToFree, "freedBytes", resp.FreedBytes)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package src
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/klog/v2"
+	sobeyapi "sobey-runtime/api/v1"
+	"sync"
+	"time"
+)
+
+// prewarmRewatchDelay is how long to wait before watching the etcd key of the
+// pre-warmed images again after the watch failed.
+const prewarmRewatchDelay = 10 * time.Second
+
+// prewarmer pulls the configured images in the background before any pod
+// uses them.
+type prewarmer struct {
+	// Images listed in the config.
+	images []string
+	// Etcd key holding a json list of more images.
+	etcdKey     string
+	concurrency int
+	period      time.Duration
+
+	mu        sync.Mutex
+	statuses  []*sobeyapi.PrewarmImageStatus
+	running   bool
+	lastRunAt int64
+}
+
+func (ss *sobeyService) PrewarmStatus(ctx context.Context, req *sobeyapi.PrewarmStatusRequest) (*sobeyapi.PrewarmStatusResponse, error) {
+	resp := &sobeyapi.PrewarmStatusResponse{}
+	p := ss.prewarmer
+	if p == nil {
+		return resp, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	resp.Running = p.running
+	resp.LastRunAt = p.lastRunAt
+	for _, status := range p.statuses {
+		resp.Images = append(resp.Images, &sobeyapi.PrewarmImageStatus{
+			Image:      status.Image,
+			State:      status.State,
+			ImageRef:   status.ImageRef,
+			Message:    status.Message,
+			StartedAt:  status.StartedAt,
+			FinishedAt: status.FinishedAt,
+		})
+	}
+	return resp, nil
+}
+
+// prewarmLoop pulls the images at startup, whenever the list in etcd changes
+// and every period to bring back the images which are missing.
+func (ss *sobeyService) prewarmLoop() {
+	p := ss.prewarmer
+	var etcdImages []string
+	var updates <-chan string
+	var rewatch <-chan time.Time
+	if p.etcdKey != "" {
+		etcdImages, updates = ss.watchPrewarmImages()
+	}
+	var tick <-chan time.Time
+	if p.period > 0 {
+		ticker := time.NewTicker(p.period)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var cancel context.CancelFunc
+	done := make(chan struct{})
+	close(done)
+	run := func() {
+		if cancel != nil {
+			cancel()
+		}
+		// Wait for the cancelled run to stop updating the statuses.
+		<-done
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		done = make(chan struct{})
+		images := mergeImages(p.images, etcdImages)
+		go func(done chan struct{}) {
+			defer close(done)
+			ss.prewarmImages(ctx, images)
+		}(done)
+	}
+	run()
+	for {
+		select {
+		case value, ok := <-updates:
+			if !ok {
+				klog.InfoS("Watch of the pre-warmed images stopped, watch again later", "key", p.etcdKey)
+				updates = nil
+				rewatch = time.After(prewarmRewatchDelay)
+				continue
+			}
+			images, err := parsePrewarmImages(value)
+			if err != nil {
+				klog.ErrorS(err, "Failed to parse the pre-warmed images", "key", p.etcdKey)
+				continue
+			}
+			etcdImages = images
+			run()
+		case <-rewatch:
+			rewatch = nil
+			etcdImages, updates = ss.watchPrewarmImages()
+			run()
+		case <-tick:
+			select {
+			case <-done:
+				run()
+			default:
+				// The last run is still pulling.
+			}
+		}
+	}
+}
+
+// watchPrewarmImages reads the images listed in etcd and watches them.
+func (ss *sobeyService) watchPrewarmImages() ([]string, <-chan string) {
+	key := ss.prewarmer.etcdKey
+	updates := ss.dbService.Watch(context.Background(), key)
+	value, err := ss.dbService.Get(key)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the pre-warmed images", "key", key)
+		return nil, updates
+	}
+	images, err := parsePrewarmImages(value)
+	if err != nil {
+		klog.ErrorS(err, "Failed to parse the pre-warmed images", "key", key)
+	}
+	return images, updates
+}
+
+// prewarmImages pulls the images missing from the image store.
+func (ss *sobeyService) prewarmImages(ctx context.Context, images []string) {
+	p := ss.prewarmer
+	statuses := make([]*sobeyapi.PrewarmImageStatus, 0, len(images))
+	for _, image := range images {
+		statuses = append(statuses, &sobeyapi.PrewarmImageStatus{Image: image})
+	}
+	p.mu.Lock()
+	p.statuses = statuses
+	p.running = true
+	p.lastRunAt = time.Now().UnixNano()
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		p.running = false
+		p.mu.Unlock()
+	}()
+
+	sem := make(chan struct{}, p.concurrency)
+	var wg sync.WaitGroup
+	// The pulls already started update their status until they return, the
+	// run is only over once all of them did.
+	defer wg.Wait()
+pulls:
+	for _, status := range statuses {
+		if img, ok := ss.imageStore.Get(imageKey(status.Image)); ok {
+			p.update(status, sobeyapi.PrewarmState_PREWARM_DONE, img.Digest, "")
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break pulls
+		}
+		wg.Add(1)
+		go func(status *sobeyapi.PrewarmImageStatus) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			p.update(status, sobeyapi.PrewarmState_PREWARM_PULLING, "", "")
+			resp, err := ss.PullImage(ctx, &runtimeapi.PullImageRequest{
+				Image: &runtimeapi.ImageSpec{Image: status.Image},
+			})
+			if err != nil {
+				klog.ErrorS(err, "Failed to pre-warm image", "image", status.Image)
+				p.update(status, sobeyapi.PrewarmState_PREWARM_FAILED, "", err.Error())
+				return
+			}
+			klog.InfoS("Pre-warmed image", "image", status.Image, "imageRef", resp.ImageRef)
+			p.update(status, sobeyapi.PrewarmState_PREWARM_DONE, resp.ImageRef, "")
+		}(status)
+	}
+}
+
+// listedImages returns the images of the last run.
+func (p *prewarmer) listedImages() []string {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	images := make([]string, 0, len(p.statuses))
+	for _, status := range p.statuses {
+		images = append(images, status.Image)
+	}
+	return images
+}
+
+func (p *prewarmer) update(status *sobeyapi.PrewarmImageStatus, state sobeyapi.PrewarmState, imageRef, message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now().UnixNano()
+	if state == sobeyapi.PrewarmState_PREWARM_PULLING {
+		status.StartedAt = now
+	} else {
+		status.FinishedAt = now
+	}
+	status.State = state
+	status.ImageRef = imageRef
+	status.Message = message
+}
+
+// parsePrewarmImages parses the json list of images in etcd, a missing or
+// empty key lists none.
+func parsePrewarmImages(value string) ([]string, error) {
+	var images []string
+	if value == "" {
+		return images, nil
+	}
+	if err := json.Unmarshal([]byte(value), &images); err != nil {
+		return nil, fmt.Errorf("pre-warmed images must be a json list of strings, err: %v", err)
+	}
+	return images, nil
+}
+
+// mergeImages returns the images of both lists in order without duplicates.
+func mergeImages(lists ...[]string) []string {
+	var images []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, image := range list {
+			key := imageKey(image)
+			if image == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			images = append(images, image)
+		}
+	}
+	return images
+}
@@ -2,41 +2,128 @@ package src
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/klog/v2"
 	"os"
 	"path/filepath"
 	"sobey-runtime/common"
+	"sobey-runtime/config"
+	"sobey-runtime/image"
+	util "sobey-runtime/utils"
+	"strings"
 	"time"
 )
 
 func (ss *sobeyService) ListImages(ctx context.Context, req *runtimeapi.ListImagesRequest) (*runtimeapi.ListImagesResponse, error) {
 	var result []*runtimeapi.Image
+	filter := ""
+	if req.Filter != nil && req.Filter.Image != nil {
+		filter = imageKey(req.Filter.Image.Image)
+	}
+	for _, img := range ss.imageStore.List() {
+		if filter != "" && img.Key() != filter && img.Digest != filter {
+			continue
+		}
+		result = append(result, toRuntimeImage(&img))
+	}
 	return &runtimeapi.ListImagesResponse{Images: result}, nil
 }
 
 func (ss *sobeyService) ImageStatus(ctx context.Context, req *runtimeapi.ImageStatusRequest) (*runtimeapi.ImageStatusResponse, error) {
-	return &runtimeapi.ImageStatusResponse{}, nil
+	img, ok := ss.imageStore.Get(imageKey(req.Image.Image))
+	if !ok {
+		return &runtimeapi.ImageStatusResponse{}, nil
+	}
+	resp := &runtimeapi.ImageStatusResponse{Image: toRuntimeImage(img)}
+	if req.Verbose {
+		bytes, err := json.Marshal(img)
+		if err != nil {
+			return nil, err
+		}
+		resp.Info = map[string]string{"info": string(bytes)}
+	}
+	return resp, nil
 }
 
 func (ss *sobeyService) PullImage(ctx context.Context, req *runtimeapi.PullImageRequest) (*runtimeapi.PullImageResponse, error) {
-	//image := req.Image.Image
-	//if strings.HasSuffix(image, ":latest") {
-	//	imageArr := strings.Split(image, ":")
-	//	image = strings.Join(imageArr[:len(imageArr)-1], ":")
-	//}
-	//srcPath := fmt.Sprintf("%s%s", ss.repo, image)
-	//desPath := filepath.Join(common.SockerImagesPath, image)
-	//err := util.DownLoadFile(srcPath, desPath)
-	//if err != nil {
-	//	fmt.Println(err)
-	//}
-	return &runtimeapi.PullImageResponse{ImageRef: req.Image.Image}, nil
+	ref, err := util.ParseImageReference(req.Image.Image)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// The repositories serve the images by name, an image pinned by digest
+	// is only pulled again when its tag still points to the same content.
+	if ref.Digest != "" {
+		if img, ok := ss.imageStore.Get(ref.Digest); ok {
+			return &runtimeapi.PullImageResponse{ImageRef: img.Digest}, nil
+		}
+		if ref.Tag == "" {
+			ref.Tag = util.DefaultImageTag
+		}
+	}
+	name := ref.StoreName()
+	cred, err := pullCredential(req.Auth)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := ss.imageStore.Pull(ctx, name, ref.Digest, image.SelectSources(ss.imageSources, name), cred)
+	if err != nil {
+		return nil, fmt.Errorf("pull image %s failed, err: %v", req.Image.Image, err)
+	}
+	klog.InfoS("Pulled image", "image", name, "digest", digest)
+	return &runtimeapi.PullImageResponse{ImageRef: digest}, nil
 }
 
 func (ss *sobeyService) RemoveImage(ctx context.Context, req *runtimeapi.RemoveImageRequest) (*runtimeapi.RemoveImageResponse, error) {
+	img, ok := ss.imageStore.Get(imageKey(req.Image.Image))
+	if !ok {
+		return &runtimeapi.RemoveImageResponse{}, nil
+	}
+	usedImages, err := ss.usedImages()
+	if err != nil {
+		return nil, err
+	}
+	if containerID, ok := usedImages[img.Key()]; ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "image %s is used by container %s", img.Key(), containerID)
+	}
+	if err = ss.imageStore.Remove(img.Key()); err != nil {
+		return nil, fmt.Errorf("remove image %s failed, err: %v", img.Key(), err)
+	}
+	klog.InfoS("Removed image", "image", img.Key(), "digest", img.Digest)
 	return &runtimeapi.RemoveImageResponse{}, nil
 }
 
+// usedImages returns the images used by the containers on this node which
+// have not exited, mapped to one of the containers using them.
+func (ss *sobeyService) usedImages() (map[string]string, error) {
+	containerInfos, err := ss.dbService.GetByPrefix(common.ContainerIDPrefix)
+	if err != nil {
+		return nil, err
+	}
+	hostname, _ := ss.os.Hostname()
+	usedImages := make(map[string]string)
+	for _, containerInfo := range containerInfos {
+		sobeyContainer := new(SobeyContainer)
+		if err = json.Unmarshal([]byte(containerInfo), sobeyContainer); err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(hostname, sobeyContainer.Hostname) {
+			continue
+		}
+		if err = ss.updateContainerExit(sobeyContainer); err != nil {
+			klog.ErrorS(err, "Failed to update exit status of container", "containerID", sobeyContainer.ID)
+		}
+		if sobeyContainer.State != runtimeapi.ContainerState_CONTAINER_EXITED {
+			usedImages[ss.storeImageName(sobeyContainer.Image)] = sobeyContainer.ID
+		}
+	}
+	return usedImages, nil
+}
+
 func (ss *sobeyService) ImageFsInfo(ctx context.Context, req *runtimeapi.ImageFsInfoRequest) (*runtimeapi.ImageFsInfoResponse, error) {
 	bytes, inodes, err := dirSize(common.SockerImagesPath)
 	if err != nil {
@@ -75,3 +162,99 @@ func dirSize(path string) (int64, int64, error) {
 	})
 	return bytes, inodes, err
 }
+
+// imageKey returns what the image store indexes the image spec by: the
+// digest for an image ID or a reference by digest only, otherwise the name
+// the image is stored under.
+func imageKey(image string) string {
+	if util.IsImageDigest(image) {
+		return image
+	}
+	ref, err := util.ParseImageReference(image)
+	if err != nil {
+		return image
+	}
+	if ref.Tag == "" {
+		return ref.Digest
+	}
+	return ref.StoreName()
+}
+
+// imageRef returns the image ID reported for a container, the digest of its
+// image which ListImages reports as the image ID too, so the kubelet image GC
+// sees the image in use. Images missing from the store are reported by name.
+func (ss *sobeyService) imageRef(image string) string {
+	if img, ok := ss.imageStore.Get(imageKey(image)); ok {
+		return img.Digest
+	}
+	return util.ToPullableImageID(image, true)
+}
+
+// storeImageName returns the name of the image in the image store which the
+// image spec refers to by name or by ID.
+func (ss *sobeyService) storeImageName(image string) string {
+	key := imageKey(image)
+	if img, ok := ss.imageStore.Get(key); ok {
+		return img.Key()
+	}
+	return key
+}
+
+// pullCredential returns the credential the kubelet sends with the pull
+// request, or nil when there is none.
+func pullCredential(auth *runtimeapi.AuthConfig) (*image.Credential, error) {
+	if auth == nil {
+		return nil, nil
+	}
+	cred := &image.Credential{Username: auth.Username, Password: auth.Password}
+	if auth.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("decode auth of the pull request failed")
+		}
+		userPass := strings.SplitN(string(decoded), ":", 2)
+		if len(userPass) != 2 {
+			return nil, fmt.Errorf("auth of the pull request is not in the \"username:password\" format")
+		}
+		cred.Username, cred.Password = userPass[0], userPass[1]
+	}
+	if auth.IdentityToken != "" {
+		cred.Token = auth.IdentityToken
+	} else if auth.RegistryToken != "" {
+		cred.Token = auth.RegistryToken
+	}
+	if cred.Empty() {
+		return nil, nil
+	}
+	return cred, nil
+}
+
+// verifyPolicy returns the verification policy of an image source, the
+// checksum is required unless the config tells otherwise.
+func verifyPolicy(conf config.ImageVerify) (image.VerifyPolicy, error) {
+	var policy image.VerifyPolicy
+	var err error
+	if policy.Checksum, err = image.ParseRequirement(conf.Checksum, image.Required); err != nil {
+		return policy, err
+	}
+	if policy.Signature, err = image.ParseRequirement(conf.Signature, image.Optional); err != nil {
+		return policy, err
+	}
+	if conf.Keyring != "" {
+		if policy.Keyring, err = image.LoadKeyring(conf.Keyring); err != nil {
+			return policy, err
+		}
+	} else if policy.Signature == image.Required {
+		return policy, fmt.Errorf("signatures are required without a keyring")
+	}
+	return policy, nil
+}
+
+func toRuntimeImage(img *image.Image) *runtimeapi.Image {
+	return &runtimeapi.Image{
+		Id:          img.Digest,
+		RepoTags:    []string{img.Name + ":" + img.Tag},
+		RepoDigests: []string{img.Name + "@" + img.Digest},
+		Size_:       uint64(img.Size),
+	}
+}
@@ -2,9 +2,20 @@ package src
 
 import (
 	"context"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/network"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sobey-runtime/cgroups"
+	"sobey-runtime/common"
+	"sobey-runtime/image"
+	"sobey-runtime/monitor"
+	util "sobey-runtime/utils"
 	"time"
 )
 
@@ -16,6 +27,16 @@ const (
 var (
 	// Termination grace period
 	defaultSandboxGracePeriod = time.Duration(10) * time.Second
+
+	// Directories the runtime keeps its state in, Status reports the runtime
+	// as not ready when one of them is not writable.
+	runtimeStateDirs = []string{
+		common.ServerLogDirPath,
+		common.SockerTempPath,
+		common.SockerImagesPath,
+		filepath.Dir(common.SockerContainerHome),
+		sobeyshimRootDir,
+	}
 )
 
 func (ss *sobeyService) Version(context.Context, *runtimeapi.VersionRequest) (*runtimeapi.VersionResponse, error) {
@@ -28,23 +49,87 @@ func (ss *sobeyService) Version(context.Context, *runtimeapi.VersionRequest) (*r
 }
 
 func (ss *sobeyService) ReopenContainerLog(ctx context.Context, req *runtimeapi.ReopenContainerLogRequest) (*runtimeapi.ReopenContainerLogResponse, error) {
+	containerInfo, err := ss.getSobeyContainer(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+	err = ss.updateContainerExit(containerInfo)
+	if err != nil {
+		klog.ErrorS(err, "Failed to update exit status of container", "containerID", containerInfo.ID)
+	}
+	if containerInfo.State != runtimeapi.ContainerState_CONTAINER_RUNNING {
+		return nil, fmt.Errorf("container %q is not running", containerInfo.ID)
+	}
+	logPath := containerInfo.Labels[common.ContainerLogPathLabelKey]
+	if _, err = os.Lstat(logPath); os.IsNotExist(err) {
+		err = ss.rotateContainerLog(containerInfo.Path, logPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rotate log of container %q: %v", containerInfo.ID, err)
+		}
+	}
+	err = monitor.ReopenLog(containerInfo.ID, containerInfo.Path)
+	if err != nil {
+		return nil, err
+	}
+	err = monitor.PruneRotatedLogs(containerInfo.Path, ss.logMaxFiles)
+	if err != nil {
+		klog.ErrorS(err, "Failed to remove rotated log files of container", "containerID", containerInfo.ID)
+	}
+	err = ss.removeDanglingLogLinks(logPath)
+	if err != nil {
+		klog.ErrorS(err, "Failed to remove rotated log links of container", "containerID", containerInfo.ID)
+	}
 	return &runtimeapi.ReopenContainerLogResponse{}, nil
 }
 
-func (ss *sobeyService) ExecSync(ctx context.Context, req *runtimeapi.ExecSyncRequest) (*runtimeapi.ExecSyncResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExecSync not implemented")
-}
-
-func (ss *sobeyService) Exec(ctx context.Context, req *runtimeapi.ExecRequest) (*runtimeapi.ExecResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
-}
-
-func (ss *sobeyService) Attach(ctx context.Context, req *runtimeapi.AttachRequest) (*runtimeapi.AttachResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Attach not implemented")
+// rotateContainerLog follows a rotation done by the kubelet, which renamed
+// the symlink at logPath. The content it rotated is moved aside to a rotated
+// file the renamed symlink is pointed to, and logPath links to realPath again
+// which the monitor recreates when it reopens the log.
+func (ss *sobeyService) rotateContainerLog(realPath, logPath string) error {
+	rotatedPath, err := monitor.RotateLogFile(realPath)
+	if err != nil {
+		return err
+	}
+	links, err := filepath.Glob(logPath + ".*")
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		target, err := os.Readlink(link)
+		if err != nil || target != realPath {
+			continue
+		}
+		if err = ss.os.Remove(link); err != nil {
+			return err
+		}
+		if err = ss.os.Symlink(rotatedPath, link); err != nil {
+			return err
+		}
+	}
+	return ss.os.Symlink(realPath, logPath)
 }
 
-func (ss *sobeyService) PortForward(ctx context.Context, req *runtimeapi.PortForwardRequest) (*runtimeapi.PortForwardResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PortForward not implemented")
+// removeDanglingLogLinks removes the symlinks renamed by the kubelet whose
+// rotated file was pruned.
+func (ss *sobeyService) removeDanglingLogLinks(logPath string) error {
+	links, err := filepath.Glob(logPath + ".*")
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		info, err := os.Lstat(link)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+		if _, err = os.Stat(link); !os.IsNotExist(err) {
+			continue
+		}
+		if err = ss.os.Remove(link); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
 }
 
 func (ss *sobeyService) ListContainerStats(ctx context.Context, req *runtimeapi.ListContainerStatsRequest) (*runtimeapi.ListContainerStatsResponse, error) {
@@ -62,9 +147,15 @@ func (ss *sobeyService) ListContainerStats(ctx context.Context, req *runtimeapi.
 	}
 	var stats []*runtimeapi.ContainerStats
 	for _, container := range listResp.Containers {
-		containerStats, err := ss.getContainerStats(container)
+		containerInfo, err := ss.getSobeyContainer(container.Id)
 		if err != nil {
-			return nil, err
+			klog.InfoS("Failed to get container for stats", "containerID", container.Id, "err", err)
+			continue
+		}
+		containerStats, err := ss.getContainerStats(containerInfo)
+		if err != nil {
+			klog.ErrorS(err, "Failed to get container stats", "containerID", container.Id)
+			continue
 		}
 		if containerStats != nil {
 			stats = append(stats, containerStats)
@@ -73,7 +164,24 @@ func (ss *sobeyService) ListContainerStats(ctx context.Context, req *runtimeapi.
 	return &runtimeapi.ListContainerStatsResponse{Stats: stats}, nil
 }
 
+// UpdateRuntimeConfig updates the runtime config. Currently only handles podCIDR updates.
 func (ss *sobeyService) UpdateRuntimeConfig(ctx context.Context, req *runtimeapi.UpdateRuntimeConfigRequest) (*runtimeapi.UpdateRuntimeConfigResponse, error) {
+	runtimeConfig := req.GetRuntimeConfig()
+	podCIDR := runtimeConfig.GetNetworkConfig().GetPodCidr()
+	if len(podCIDR) == 0 {
+		return &runtimeapi.UpdateRuntimeConfigResponse{}, nil
+	}
+
+	klog.InfoS("Sobey cri received runtime config", "runtimeConfig", runtimeConfig)
+	err := ss.UpdateIpRange(podCIDR)
+	if err != nil {
+		return nil, err
+	}
+	if ss.network != nil {
+		event := make(map[string]interface{})
+		event[network.NET_PLUGIN_EVENT_POD_CIDR_CHANGE_DETAIL_CIDR] = podCIDR
+		ss.network.Event(network.NET_PLUGIN_EVENT_POD_CIDR_CHANGE, event)
+	}
 	return &runtimeapi.UpdateRuntimeConfigResponse{}, nil
 }
 func (ss *sobeyService) Status(ctx context.Context, req *runtimeapi.StatusRequest) (*runtimeapi.StatusResponse, error) {
@@ -86,33 +194,132 @@ func (ss *sobeyService) Status(ctx context.Context, req *runtimeapi.StatusReques
 		Status: true,
 	}
 	conditions := []*runtimeapi.RuntimeCondition{runtimeReady, networkReady}
+	if err := ss.dbService.Ping(); err != nil {
+		setConditionFailed(runtimeReady, "EtcdNotReachable", fmt.Sprintf("sobey: failed to reach etcd: %v", err))
+	}
+	if _, err := exec.LookPath("socker"); err != nil {
+		setConditionFailed(runtimeReady, "SockerNotFound", fmt.Sprintf("sobey: socker binary is not found: %v", err))
+	}
+	for _, dir := range runtimeStateDirs {
+		if err := checkDirWritable(dir); err != nil {
+			setConditionFailed(runtimeReady, "StateDirNotWritable", fmt.Sprintf("sobey: state dir %s is not writable: %v", dir, err))
+		}
+	}
+	if err := ss.network.Status(); err != nil {
+		setConditionFailed(networkReady, "NetworkPluginNotReady", fmt.Sprintf("sobey: network plugin is not ready: %v", err))
+	}
 	runtimeStatus := &runtimeapi.RuntimeStatus{Conditions: conditions}
-	return &runtimeapi.StatusResponse{Status: runtimeStatus}, nil
+	resp := &runtimeapi.StatusResponse{Status: runtimeStatus}
+	if req.Verbose {
+		var sourceStatuses []image.SourceStatus
+		for _, source := range ss.imageSources {
+			sourceStatuses = append(sourceStatuses, source.Status())
+		}
+		bytes, err := json.Marshal(sourceStatuses)
+		if err != nil {
+			return nil, err
+		}
+		resp.Info = map[string]string{"imageSources": string(bytes)}
+	}
+	return resp, nil
 }
 
-func (ss *sobeyService) getContainerStats(c *runtimeapi.Container) (*runtimeapi.ContainerStats, error) {
-	timestamp := time.Now().UnixNano()
+// setConditionFailed marks the condition as failed. The reason of the first
+// failure is kept, and the messages of all failures are joined.
+func setConditionFailed(condition *runtimeapi.RuntimeCondition, reason, message string) {
+	if condition.Status {
+		condition.Status = false
+		condition.Reason = reason
+		condition.Message = message
+		return
+	}
+	condition.Message = fmt.Sprintf("%s; %s", condition.Message, message)
+}
+
+func checkDirWritable(dir string) error {
+	f, err := ioutil.TempFile(dir, ".status-")
+	if err != nil {
+		return err
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
+func (ss *sobeyService) getContainerStats(containerInfo *SobeyContainer) (*runtimeapi.ContainerStats, error) {
+	metadata, err := util.ParseContainerName(containerInfo.Name)
+	if err != nil {
+		return nil, err
+	}
+	labels, annotations := util.ExtractLabels(containerInfo.Labels)
 	containerStats := &runtimeapi.ContainerStats{
 		Attributes: &runtimeapi.ContainerAttributes{
-			Id:          c.Id,
-			Metadata:    c.Metadata,
-			Labels:      c.Labels,
-			Annotations: c.Annotations,
-		},
-		// TODO calculate every server physic usage
-		Cpu: &runtimeapi.CpuUsage{
-			Timestamp:            timestamp,
-			UsageCoreNanoSeconds: &runtimeapi.UInt64Value{Value: 1},
-		},
-		Memory: &runtimeapi.MemoryUsage{
-			Timestamp:       timestamp,
-			WorkingSetBytes: &runtimeapi.UInt64Value{Value: 1},
-		},
-		WritableLayer: &runtimeapi.FilesystemUsage{
-			Timestamp: timestamp,
-			//FsId:      &runtimeapi.FilesystemIdentifier{Mountpoint: common.SockerImagesPath},
-			UsedBytes: &runtimeapi.UInt64Value{Value: 1},
+			Id:          containerInfo.ID,
+			Metadata:    metadata,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 	}
+
+	// Only a running container has a cgroup to read the usage from.
+	if containerInfo.State == runtimeapi.ContainerState_CONTAINER_RUNNING {
+		cgroupStats, err := getCgroupStats(containerInfo.Pid)
+		if err != nil {
+			klog.ErrorS(err, "Failed to get cgroup stats of container", "containerID", containerInfo.ID)
+		} else {
+			timestamp := time.Now().UnixNano()
+			containerStats.Cpu = &runtimeapi.CpuUsage{
+				Timestamp:            timestamp,
+				UsageCoreNanoSeconds: &runtimeapi.UInt64Value{Value: cgroupStats.CpuUsageNanos},
+			}
+			containerStats.Memory = &runtimeapi.MemoryUsage{
+				Timestamp:       timestamp,
+				WorkingSetBytes: &runtimeapi.UInt64Value{Value: cgroupStats.WorkingSetBytes},
+			}
+		}
+	}
+
+	layerPath := writableLayerPath(containerInfo.ID)
+	usedBytes, inodesUsed, err := util.DiskUsage(layerPath)
+	if err != nil && !os.IsNotExist(err) {
+		klog.ErrorS(err, "Failed to get writable layer usage of container", "containerID", containerInfo.ID, "path", layerPath)
+		return containerStats, nil
+	}
+	containerStats.WritableLayer = &runtimeapi.FilesystemUsage{
+		Timestamp:  time.Now().UnixNano(),
+		FsId:       &runtimeapi.FilesystemIdentifier{Mountpoint: layerPath},
+		UsedBytes:  &runtimeapi.UInt64Value{Value: usedBytes},
+		InodesUsed: &runtimeapi.UInt64Value{Value: inodesUsed},
+	}
 	return containerStats, nil
 }
+
+// writableLayerPath returns the overlay upperdir of the container rootfs, or
+// the fs dir of the container when the rootfs is not mounted. The merged
+// rootfs is never measured, it holds the image and the volume, proc and sys
+// mounts.
+func writableLayerPath(id string) string {
+	fsPath := fmt.Sprintf(common.SockerContainerFSHome, id)
+	mounts, err := util.ReadMountInfo("self")
+	if err != nil {
+		klog.ErrorS(err, "Failed to read mountinfo", "containerID", id)
+		return fsPath
+	}
+	mntPath := fsPath + "/mnt"
+	for _, mount := range mounts {
+		if mount.MountPoint != mntPath || mount.FSType != "overlay" {
+			continue
+		}
+		if upperDir, ok := mount.SuperOption("upperdir"); ok && upperDir != "" {
+			return upperDir
+		}
+	}
+	return fsPath
+}
+
+func getCgroupStats(pid string) (*cgroups.Stats, error) {
+	cgroup, err := cgroups.Load(pid)
+	if err != nil {
+		return nil, err
+	}
+	return cgroup.Stats()
+}
@@ -1,106 +1,246 @@
 package src
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"net"
+	"sobey-runtime/common"
 	"strings"
 )
 
 type SobeyCniInterface interface {
 	InitIpRange() error
+	UpdateIpRange(podCIDR string) error
 	NewSandboxIP() (string, error)
 	PutReleasedIP(ip string) error
 }
 
+// ipPool is the etcd keys of the state of an IP pool. The nodes share the
+// pool of the configured ip range until the kubelet applies a pod CIDR to a
+// node, which then allocates from a pool of its own.
+type ipPool struct {
+	ranges     string
+	latestIP   string
+	releasedIP string
+}
+
+// sharedIPPool is the pool of the configured ip range shared by the nodes.
+var sharedIPPool = ipPool{ranges: "ipRanges", latestIP: "latestIp", releasedIP: "releasedIp"}
+
+// nodeIPPool returns the pool of the pod CIDR of this node.
+func (ss *sobeyService) nodeIPPool() (ipPool, error) {
+	hostname, err := ss.os.Hostname()
+	if err != nil {
+		return ipPool{}, err
+	}
+	prefix := fmt.Sprintf("%s_%s_", common.IPAMPrefix, strings.ToLower(hostname))
+	return ipPool{
+		ranges:     prefix + sharedIPPool.ranges,
+		latestIP:   prefix + sharedIPPool.latestIP,
+		releasedIP: prefix + sharedIPPool.releasedIP,
+	}, nil
+}
+
+// currentIPPool returns the pool this node allocates from, its own pool once
+// a pod CIDR is applied and the shared pool before.
+func currentIPPool(stm concurrency.STM, node ipPool) ipPool {
+	if len(stm.Get(node.ranges)) != 0 {
+		return node
+	}
+	return sharedIPPool
+}
+
 func (ss *sobeyService) InitIpRange() error {
-	err := ss.dbService.Put("ipRanges", ss.ipRange)
+	// Nodes without a configured range get the pod CIDR from the kubelet,
+	// see UpdateIpRange.
+	if len(ss.ipRange) == 0 {
+		return nil
+	}
+	err := ss.dbService.Put(sharedIPPool.ranges, ss.ipRange)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (ss *sobeyService) NewSandboxIP() (string, error) {
-	var ip string
-	var releasedIPMap map[string]struct{}
-	releasedIPStr, err := ss.dbService.Get("releasedIp")
+// UpdateIpRange switches this node to a pool of the pod CIDR. When the node
+// leaves the shared pool the state of the shared pool within the pod CIDR
+// moves to the new pool, so the IPs held by running sandboxes are not handed
+// out again. The state which does not belong to a new range is dropped, so
+// the next sandbox gets the first free IP of the new range.
+func (ss *sobeyService) UpdateIpRange(podCIDR string) error {
+	_, ipNet, err := net.ParseCIDR(podCIDR)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("invalid pod CIDR %q: %v", podCIDR, err)
 	}
-	if len(releasedIPStr) != 0 {
-		err = json.Unmarshal([]byte(releasedIPStr), &releasedIPMap)
-		if err != nil {
-			return "", err
+	node, err := ss.nodeIPPool()
+	if err != nil {
+		return err
+	}
+	err = ss.dbService.Txn(func(stm concurrency.STM) error {
+		ipRanges := stm.Get(node.ranges)
+		if ipRanges == podCIDR {
+			return nil
 		}
-		for key := range releasedIPMap {
-			ip = key
-			break
+		from := node
+		if len(ipRanges) == 0 {
+			from = sharedIPPool
 		}
-		delete(releasedIPMap, ip)
-		if len(releasedIPMap) == 0 {
-			_ = ss.dbService.Delete("releasedIp")
+		stm.Put(node.ranges, podCIDR)
+
+		latestIP := stm.Get(from.latestIP)
+		if len(latestIP) != 0 && ipNet.Contains(net.ParseIP(latestIP)) {
+			stm.Put(node.latestIP, latestIP)
 		} else {
-			bytes, err := json.Marshal(releasedIPMap)
-			if err != nil {
-				return "", err
+			stm.Del(node.latestIP)
+		}
+
+		releasedIPMap, err := getReleasedIPs(stm, from.releasedIP)
+		if err != nil {
+			return err
+		}
+		moved := make(map[string]struct{})
+		for ip := range releasedIPMap {
+			if ipNet.Contains(net.ParseIP(ip)) {
+				moved[ip] = struct{}{}
+				delete(releasedIPMap, ip)
 			}
-			err = ss.dbService.Put("releasedIp", string(bytes))
-			if err != nil {
-				return "", err
+		}
+		if from == sharedIPPool {
+			// The IPs out of the pod CIDR stay free for the other nodes.
+			if err = putReleasedIPs(stm, from.releasedIP, releasedIPMap); err != nil {
+				return err
 			}
 		}
-	} else {
-		latestIP, err := ss.dbService.Get("latestIp")
+		return putReleasedIPs(stm, node.releasedIP, moved)
+	})
+	if err != nil {
+		return err
+	}
+	ss.ipRange = podCIDR
+	return nil
+}
+
+func (ss *sobeyService) NewSandboxIP() (string, error) {
+	node, err := ss.nodeIPPool()
+	if err != nil {
+		return "", err
+	}
+	var ip string
+	err = ss.dbService.Txn(func(stm concurrency.STM) error {
+		ip = ""
+		pool := currentIPPool(stm, node)
+		releasedIPMap, err := getReleasedIPs(stm, pool.releasedIP)
 		if err != nil {
-			return "", err
+			return err
 		}
-		var lastIPBit int
-		if len(latestIP) != 0 {
-			latestIPArr := strings.Split(latestIP, ".")
-			lastIPBit, err = strconv.Atoi(latestIPArr[3])
-			if err != nil {
-				return "", err
-			}
-			ip = fmt.Sprintf("%s.%s.%s.%s", latestIPArr[0],
-				latestIPArr[1], latestIPArr[2], strconv.Itoa(lastIPBit+1))
-		} else {
-			ipRanges, err := ss.dbService.Get("ipRanges")
-			if err != nil {
-				return "", err
+		if len(releasedIPMap) != 0 {
+			for key := range releasedIPMap {
+				ip = key
+				break
 			}
-			ipRangeArr := strings.Split(strings.Split(ipRanges, "/")[0], ".")
-			ip = fmt.Sprintf("%s.%s.%s.%s", ipRangeArr[0],
-				ipRangeArr[1], ipRangeArr[2], strconv.Itoa(1))
+			delete(releasedIPMap, ip)
+			return putReleasedIPs(stm, pool.releasedIP, releasedIPMap)
+		}
+		ipRanges := stm.Get(pool.ranges)
+		if len(ipRanges) == 0 {
+			return fmt.Errorf("no ip range is assigned to this node")
 		}
-		err = ss.dbService.Put("latestIp", ip)
+		_, ipNet, err := net.ParseCIDR(ipRanges)
 		if err != nil {
-			return "", err
+			return fmt.Errorf("invalid ip range %q: %v", ipRanges, err)
 		}
+		next, err := nextIP(ipNet, net.ParseIP(stm.Get(pool.latestIP)))
+		if err != nil {
+			return err
+		}
+		ip = next.String()
+		stm.Put(pool.latestIP, ip)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return ip, nil
+}
+
+// nextIP returns the IP following latest in the range, or the first IP of
+// the range when latest is not in it. The network and the broadcast
+// addresses are never returned.
+func nextIP(ipNet *net.IPNet, latest net.IP) (net.IP, error) {
+	network := ipNet.IP.To4()
+	if network == nil {
+		return nil, fmt.Errorf("ip range %s is not an IPv4 range", ipNet)
 	}
-	return ip, err
+	ones, bits := ipNet.Mask.Size()
+	first := binary.BigEndian.Uint32(network) + 1
+	broadcast := binary.BigEndian.Uint32(network) | (1<<uint(bits-ones) - 1)
+	next := first
+	if latest4 := latest.To4(); latest4 != nil && ipNet.Contains(latest4) {
+		next = binary.BigEndian.Uint32(latest4) + 1
+	}
+	if next < first || next >= broadcast {
+		return nil, fmt.Errorf("ip range %s is exhausted", ipNet)
+	}
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, next)
+	return ip, nil
 }
 
+// PutReleasedIP frees the IP in the pool it belongs to, the IPs allocated
+// from the shared pool before the node got a pod CIDR go back to it.
 func (ss *sobeyService) PutReleasedIP(ip string) error {
-	var releasedIPMap map[string]struct{}
-	releasedIPStr, err := ss.dbService.Get("releasedIp")
+	node, err := ss.nodeIPPool()
 	if err != nil {
 		return err
 	}
-	if len(releasedIPStr) != 0 {
-		err = json.Unmarshal([]byte(releasedIPStr), &releasedIPMap)
+	return ss.dbService.Txn(func(stm concurrency.STM) error {
+		pool := sharedIPPool
+		if ipRanges := stm.Get(node.ranges); len(ipRanges) != 0 {
+			_, ipNet, err := net.ParseCIDR(ipRanges)
+			if err == nil && ipNet.Contains(net.ParseIP(ip)) {
+				pool = node
+			}
+		}
+		releasedIPMap, err := getReleasedIPs(stm, pool.releasedIP)
 		if err != nil {
 			return err
 		}
-		releasedIPMap[ip] = struct{}{}
-	} else {
-		releasedIPMap = map[string]struct{}{
-			ip: {},
+		if releasedIPMap == nil {
+			releasedIPMap = make(map[string]struct{})
 		}
+		releasedIPMap[ip] = struct{}{}
+		return putReleasedIPs(stm, pool.releasedIP, releasedIPMap)
+	})
+}
+
+// getReleasedIPs reads the released IPs, the map is nil when there are none.
+func getReleasedIPs(stm concurrency.STM, key string) (map[string]struct{}, error) {
+	releasedIPStr := stm.Get(key)
+	if len(releasedIPStr) == 0 {
+		return nil, nil
+	}
+	var releasedIPMap map[string]struct{}
+	err := json.Unmarshal([]byte(releasedIPStr), &releasedIPMap)
+	if err != nil {
+		return nil, err
+	}
+	return releasedIPMap, nil
+}
+
+// putReleasedIPs writes the released IPs, the key is deleted when there are
+// none.
+func putReleasedIPs(stm concurrency.STM, key string, releasedIPMap map[string]struct{}) error {
+	if len(releasedIPMap) == 0 {
+		stm.Del(key)
+		return nil
 	}
 	bytes, err := json.Marshal(releasedIPMap)
 	if err != nil {
 		return err
 	}
-	return ss.dbService.Put("releasedIp", string(bytes))
+	stm.Put(key, string(bytes))
+	return nil
 }
@@ -0,0 +1,35 @@
+package src
+
+import (
+	"net"
+	"testing"
+)
+
+func TestNextIP(t *testing.T) {
+	_, ipNet, _ := net.ParseCIDR("172.244.1.0/30")
+	for _, tc := range []struct {
+		latest   string
+		expected string
+	}{
+		{"", "172.244.1.1"},
+		{"172.244.1.1", "172.244.1.2"},
+		{"172.244.1.2", ""},
+		{"172.244.0.9", "172.244.1.1"},
+	} {
+		ip, err := nextIP(ipNet, net.ParseIP(tc.latest))
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("%q: expected the range to be exhausted, got %s", tc.latest, ip)
+			}
+			continue
+		}
+		if err != nil || ip.String() != tc.expected {
+			t.Errorf("%q: expected %s, got %s, err: %v", tc.latest, tc.expected, ip, err)
+		}
+	}
+
+	_, ipNet, _ = net.ParseCIDR("10.1.0.0/16")
+	if ip, err := nextIP(ipNet, net.ParseIP("10.1.0.255")); err != nil || ip.String() != "10.1.1.0" {
+		t.Errorf("expected 10.1.1.0 after 10.1.0.255, got %s, err: %v", ip, err)
+	}
+}
@@ -4,18 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/mitchellh/go-ps"
 	"golang.org/x/sys/unix"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"io"
 	"io/ioutil"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 	"k8s.io/klog/v2"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sobey-runtime/cgroups"
 	"sobey-runtime/common"
+	"sobey-runtime/filter"
 	"sobey-runtime/module"
+	"sobey-runtime/monitor"
 	util "sobey-runtime/utils"
 	"strconv"
 	"strings"
@@ -39,8 +39,27 @@ type SobeyContainer struct {
 	CreateAt         int64                        `json:"createAt"`
 	StartedAt        int64                        `json:"startedAt"`
 	FinishedAt       int64                        `json:"finishedAt"`
+	ExitCode         int32                        `json:"exitCode"`
+	Signal           string                       `json:"signal"`
+	OOMKilled        bool                         `json:"oomKilled"`
 }
 
+const (
+	// How long StopContainer waits for the monitor to record the exit status
+	// after the container is killed.
+	containerExitWaitTimeout = 2 * time.Second
+
+	// Reasons of an exited container.
+	containerReasonCompleted = "Completed"
+	containerReasonError     = "Error"
+	containerReasonOOMKilled = "OOMKilled"
+
+	// Same limits as the kubelet applies to the termination message.
+	maxTerminationMessageLength    = 4 * 1024
+	maxTerminationMessageLogLines  = 80
+	maxTerminationMessageLogLength = 2 * 1024
+)
+
 type ContainerStartResult struct {
 	Name         string `json:"name"`
 	Pid          string `json:"pid"`
@@ -49,6 +68,23 @@ type ContainerStartResult struct {
 	FinishedTime int64  `json:"finished_time"`
 }
 
+// getSobeyContainer loads the record of a container from etcd.
+func (ss *sobeyService) getSobeyContainer(containerID string) (*SobeyContainer, error) {
+	res, err := ss.dbService.Get(util.BuildContainerID(containerID))
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("Container is not exists, containerID: %s ", containerID)
+	}
+	containerInfo := new(SobeyContainer)
+	err = json.Unmarshal([]byte(res), &containerInfo)
+	if err != nil {
+		return nil, err
+	}
+	return containerInfo, nil
+}
+
 func (ss *sobeyService) ListContainers(ctx context.Context, req *runtimeapi.ListContainersRequest) (*runtimeapi.ListContainersResponse, error) {
 	var result []*runtimeapi.Container
 	containerInfos, err := ss.dbService.GetByPrefix(common.ContainerIDPrefix)
@@ -65,13 +101,13 @@ func (ss *sobeyService) ListContainers(ctx context.Context, req *runtimeapi.List
 			}
 			hostname, _ := ss.os.Hostname()
 			if strings.EqualFold(hostname, sobeyContainer.Hostname) {
+				err = ss.updateContainerExit(sobeyContainer)
+				if err != nil {
+					klog.ErrorS(err, "Failed to update exit status of container", "containerID", sobeyContainer.ID)
+				}
 				sobeyContainers = append(sobeyContainers, sobeyContainer)
 			}
 		}
-		if len(sobeyContainers) == 0 {
-			return &runtimeapi.ListContainersResponse{Containers: result}, nil
-		}
-		sobeyContainers = filterContainers(req.GetFilter(), sobeyContainers)
 		for _, containerInfo := range sobeyContainers {
 			metadata, err := util.ParseContainerName(containerInfo.Name)
 			if err != nil {
@@ -83,7 +119,7 @@ func (ss *sobeyService) ListContainers(ctx context.Context, req *runtimeapi.List
 				PodSandboxId: containerInfo.Labels[common.SandboxIDLabelKey],
 				Metadata:     metadata,
 				Image:        &runtimeapi.ImageSpec{Image: containerInfo.Image},
-				ImageRef:     util.ToPullableImageID(containerInfo.Image, true),
+				ImageRef:     ss.imageRef(containerInfo.Image),
 				State:        containerInfo.State,
 				CreatedAt:    containerInfo.CreateAt,
 				Labels:       labels,
@@ -91,61 +127,8 @@ func (ss *sobeyService) ListContainers(ctx context.Context, req *runtimeapi.List
 			})
 		}
 	}
-	return &runtimeapi.ListContainersResponse{Containers: result}, nil
-}
-
-func filterContainers(filter *runtimeapi.ContainerFilter, containers []*SobeyContainer) []*SobeyContainer {
-	if filter == nil {
-		return containers
-	}
-	var idFilterItems []*SobeyContainer
-	if len(filter.Id) != 0 {
-		for _, containerInfo := range containers {
-			if strings.EqualFold(filter.Id, containerInfo.ID) {
-				idFilterItems = append(idFilterItems, containerInfo)
-			}
-		}
-	} else {
-		idFilterItems = containers
-	}
-
-	var sandboxIdFilterItems []*SobeyContainer
-	if len(filter.PodSandboxId) != 0 {
-		for _, containerInfo := range idFilterItems {
-			if strings.EqualFold(util.RemoveSandboxIDPrefix(filter.PodSandboxId),
-				util.RemoveSandboxIDPrefix(containerInfo.Labels[common.SandboxIDLabelKey])) {
-				sandboxIdFilterItems = append(sandboxIdFilterItems, containerInfo)
-			}
-		}
-	} else {
-		sandboxIdFilterItems = idFilterItems
-	}
-
-	var uidFilterItems []*SobeyContainer
-	if uid, ok := filter.LabelSelector[common.KubernetesPodUIDLabel]; ok && len(uid) != 0 {
-		for _, containerInfo := range sandboxIdFilterItems {
-			if strings.EqualFold(filter.LabelSelector[common.KubernetesPodUIDLabel],
-				containerInfo.Uid) {
-				uidFilterItems = append(uidFilterItems, containerInfo)
-			}
-		}
-	} else {
-		uidFilterItems = sandboxIdFilterItems
-	}
-
-	var stateFilerItems []*SobeyContainer
-	if filter.State != nil {
-		for _, containerInfo := range uidFilterItems {
-			if filter.State.State == containerInfo.State {
-				stateFilerItems = append(stateFilerItems, containerInfo)
-			}
-		}
-	} else {
-		stateFilerItems = uidFilterItems
-	}
-	return stateFilerItems
+	return &runtimeapi.ListContainersResponse{Containers: filter.Containers(req.GetFilter(), result)}, nil
 }
-
 func (ss *sobeyService) CreateContainer(ctx context.Context, req *runtimeapi.CreateContainerRequest) (*runtimeapi.CreateContainerResponse, error) {
 	config := req.GetConfig()
 	if config == nil {
@@ -177,10 +160,10 @@ func (ss *sobeyService) CreateContainer(ctx context.Context, req *runtimeapi.Cre
 	}
 
 	apiVersion := common.SobeyRuntimeApiVersion
-	image := config.Image.Image
-	if strings.HasSuffix(image, ":latest") {
-		imageArr := strings.Split(image, ":")
-		image = strings.Join(imageArr[:len(imageArr)-1], ":")
+	// The kubelet creates containers from the image ID PullImage returns.
+	image := ss.storeImageName(config.Image.Image)
+	if err = ss.imageStore.Touch(image); err != nil {
+		klog.ErrorS(err, "Failed to update the last used time of the image", "image", image)
 	}
 	containerName := util.MakeContainerName(sandboxConfig, config)
 
@@ -242,7 +225,6 @@ func (ss *sobeyService) StartContainer(ctx context.Context, req *runtimeapi.Star
 	}
 	containerInfo.Pid = startRes.Pid
 	containerInfo.StartedAt = startRes.UpTime
-	containerInfo.FinishedAt = startRes.UpTime + 1000
 	containerInfo.State = runtimeapi.ContainerState_CONTAINER_RUNNING
 	bytes, err := json.Marshal(containerInfo)
 	if err != nil {
@@ -291,15 +273,15 @@ func (ss *sobeyService) startServer(info SobeyContainer) (*ContainerStartResult,
 		if err != nil {
 			return nil, err
 		}
-		sockerArgs := []string{
-			"run",
-			info.ID,
-		}
-		command := exec.Command("socker", sockerArgs...)
-		command.Stdin = os.Stdin
-		command.Stdout = os.Stdout
-		command.Stderr = os.Stderr
-		err = command.Start()
+		// The monitor runs `socker run` and owns its stdio.
+		err = monitor.Start(monitor.Options{
+			ID:          info.ID,
+			LogPath:     info.Path,
+			Stdin:       info.ContainerConfig.GetStdin(),
+			StdinOnce:   info.ContainerConfig.GetStdinOnce(),
+			LogMaxSize:  ss.logMaxSize,
+			LogMaxFiles: ss.logMaxFiles,
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -371,28 +353,35 @@ func writeConfFile(info SobeyContainer, imageName, imageTag,
 
 	linuxResource := info.ContainerConfig.Linux.Resources
 	if linuxResource != nil {
-		var hugePageLimits []module.HugepageLimit
-		for _, limit := range linuxResource.HugepageLimits {
-			hugePageLimits = append(hugePageLimits, module.HugepageLimit{
-				PageSize: limit.PageSize,
-				Limit:    limit.Limit,
-			})
-		}
-		conf.Resource = module.Resource{
-			CpuPeriod:              linuxResource.CpuPeriod,
-			CpuQuota:               linuxResource.CpuQuota,
-			CpuShares:              linuxResource.CpuShares,
-			MemoryLimitInBytes:     linuxResource.MemoryLimitInBytes,
-			OomScoreAdj:            linuxResource.OomScoreAdj,
-			CpusetCpus:             linuxResource.CpusetCpus,
-			CpusetMems:             linuxResource.CpusetMems,
-			HugepageLimits:         hugePageLimits,
-			Unified:                linuxResource.Unified,
-			MemorySwapLimitInBytes: linuxResource.MemorySwapLimitInBytes,
-		}
+		conf.Resource = toSockerResource(linuxResource)
+	}
+	return saveConfFile(info.ID, conf)
+}
+
+func toSockerResource(linuxResource *runtimeapi.LinuxContainerResources) module.Resource {
+	var hugePageLimits []module.HugepageLimit
+	for _, limit := range linuxResource.HugepageLimits {
+		hugePageLimits = append(hugePageLimits, module.HugepageLimit{
+			PageSize: limit.PageSize,
+			Limit:    limit.Limit,
+		})
 	}
+	return module.Resource{
+		CpuPeriod:              linuxResource.CpuPeriod,
+		CpuQuota:               linuxResource.CpuQuota,
+		CpuShares:              linuxResource.CpuShares,
+		MemoryLimitInBytes:     linuxResource.MemoryLimitInBytes,
+		OomScoreAdj:            linuxResource.OomScoreAdj,
+		CpusetCpus:             linuxResource.CpusetCpus,
+		CpusetMems:             linuxResource.CpusetMems,
+		HugepageLimits:         hugePageLimits,
+		Unified:                linuxResource.Unified,
+		MemorySwapLimitInBytes: linuxResource.MemorySwapLimitInBytes,
+	}
+}
 
-	confPath := fmt.Sprintf(common.SockerContainerConfHome, info.ID)
+func saveConfFile(id string, conf *module.ContainerConf) error {
+	confPath := fmt.Sprintf(common.SockerContainerConfHome, id)
 	err := util.CreateDirsIfDontExist([]string{confPath})
 	if err != nil {
 		return err
@@ -405,6 +394,36 @@ func writeConfFile(info SobeyContainer, imageName, imageTag,
 	return ioutil.WriteFile(confFilePath, bytes, 0777)
 }
 
+// updateConfFile updates the resources in the socker config of a started
+// container, containers which are not started yet get the config from their
+// record when they start.
+func updateConfFile(id string, linuxResource *runtimeapi.LinuxContainerResources) error {
+	conf, err := readConfFile(id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	conf.Mem = linuxResource.MemoryLimitInBytes
+	conf.Swap = linuxResource.MemorySwapLimitInBytes
+	conf.Resource = toSockerResource(linuxResource)
+	return saveConfFile(id, conf)
+}
+
+func readConfFile(id string) (*module.ContainerConf, error) {
+	bytes, err := ioutil.ReadFile(fmt.Sprintf("%s/config.json", fmt.Sprintf(common.SockerContainerConfHome, id)))
+	if err != nil {
+		return nil, err
+	}
+	conf := new(module.ContainerConf)
+	err = json.Unmarshal(bytes, &conf)
+	if err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
 func (ss *sobeyService) StopContainer(ctx context.Context, req *runtimeapi.StopContainerRequest) (*runtimeapi.StopContainerResponse, error) {
 	res, err := ss.dbService.Get(util.BuildContainerID(req.ContainerId))
 	if err != nil {
@@ -419,11 +438,19 @@ func (ss *sobeyService) StopContainer(ctx context.Context, req *runtimeapi.StopC
 	if err != nil {
 		return nil, err
 	}
-	err = stopServer(containerInfo.Pid)
+	if containerInfo.State == runtimeapi.ContainerState_CONTAINER_EXITED {
+		return &runtimeapi.StopContainerResponse{}, nil
+	}
+	exitStatus, err := stopProcess(containerInfo.ID, containerInfo.Pid, time.Duration(req.Timeout)*time.Second)
 	if err != nil {
 		return nil, err
 	}
-	containerInfo.State = runtimeapi.ContainerState_CONTAINER_EXITED
+	if exitStatus != nil {
+		setExitStatus(&containerInfo, exitStatus)
+	} else {
+		containerInfo.State = runtimeapi.ContainerState_CONTAINER_EXITED
+		containerInfo.FinishedAt = time.Now().UnixNano()
+	}
 	bytes, err := json.Marshal(containerInfo)
 	if err != nil {
 		return nil, err
@@ -435,39 +462,169 @@ func (ss *sobeyService) StopContainer(ctx context.Context, req *runtimeapi.StopC
 	return &runtimeapi.StopContainerResponse{}, nil
 }
 
-func stopServer(pidStr string) error {
-	list, err := ps.Processes()
+// updateContainerExit fills in the exit status recorded by the monitor of a
+// running container, and persists the record once the container exited.
+func (ss *sobeyService) updateContainerExit(containerInfo *SobeyContainer) error {
+	if containerInfo.State != runtimeapi.ContainerState_CONTAINER_RUNNING {
+		return nil
+	}
+	exitStatus, err := monitor.ReadExitStatus(containerInfo.ID)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
 		return err
 	}
-	pid, err := strconv.Atoi(pidStr)
+	setExitStatus(containerInfo, exitStatus)
+	bytes, err := json.Marshal(containerInfo)
 	if err != nil {
 		return err
 	}
-	process, err := os.FindProcess(pid)
+	return ss.dbService.PutWithPrefix(common.ContainerIDPrefix, containerInfo.ID, string(bytes))
+}
+
+func setExitStatus(containerInfo *SobeyContainer, exitStatus *monitor.ExitStatus) {
+	containerInfo.State = runtimeapi.ContainerState_CONTAINER_EXITED
+	containerInfo.ExitCode = exitStatus.ExitCode
+	containerInfo.FinishedAt = exitStatus.ExitedAt
+	containerInfo.Signal = exitStatus.Signal
+	containerInfo.OOMKilled = exitStatus.OOMKilled
+}
+
+// terminationReason returns the reason of an exited container.
+func terminationReason(containerInfo *SobeyContainer) string {
+	switch {
+	case containerInfo.OOMKilled:
+		return containerReasonOOMKilled
+	case containerInfo.ExitCode == 0:
+		return containerReasonCompleted
+	default:
+		return containerReasonError
+	}
+}
+
+// terminationMessage reads the message the container wrote to its
+// termination message path. With the FallbackToLogsOnError policy the tail of
+// the container log is used when a failed container wrote no message.
+func terminationMessage(containerInfo *SobeyContainer, annotations map[string]string) string {
+	var message string
+	messagePath := annotations[common.KubernetesContainerTerminationMessagePathLabel]
+	for _, mount := range containerInfo.ContainerConfig.GetMounts() {
+		if len(messagePath) == 0 || mount.ContainerPath != messagePath {
+			continue
+		}
+		content, err := readFileTail(mount.HostPath, maxTerminationMessageLength)
+		if err != nil {
+			klog.InfoS("Failed to read termination message of container", "containerID", containerInfo.ID, "path", mount.HostPath, "err", err)
+		}
+		message = content
+		break
+	}
+	if len(message) != 0 || containerInfo.ExitCode == 0 ||
+		annotations[common.KubernetesContainerTerminationMessagePolicyLabel] != common.TerminationMessageFallbackToLogsOnError {
+		return message
+	}
+	message, err := monitor.TailLog(containerInfo.Labels[common.ContainerLogPathLabelKey],
+		maxTerminationMessageLogLines, maxTerminationMessageLogLength)
 	if err != nil {
-		return err
+		klog.InfoS("Failed to read log of container", "containerID", containerInfo.ID, "err", err)
 	}
-	err = process.Kill()
+	return message
+}
+
+func readFileTail(path string, maxBytes int64) (string, error) {
+	f, err := os.Open(path)
 	if err != nil {
-		return err
+		return "", err
 	}
-	_, _ = process.Wait()
-	for _, p := range list {
-		if p.Pid() == pid {
-			parentProcPID := p.PPid()
-			ppProcess, err := os.FindProcess(parentProcPID)
-			if err != nil {
-				return err
-			}
-			err = ppProcess.Kill()
-			if err != nil {
-				return err
-			}
-			_, _ = ppProcess.Wait()
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.Size() > maxBytes {
+		if _, err = f.Seek(-maxBytes, io.SeekEnd); err != nil {
+			return "", err
 		}
 	}
-	return err
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// waitExitStatus waits for the monitor of a killed container to record its
+// exit status, it returns nil if nothing is recorded before the timeout.
+func waitExitStatus(id string, timeout time.Duration) *monitor.ExitStatus {
+	deadline := time.Now().Add(timeout)
+	for {
+		exitStatus, err := monitor.ReadExitStatus(id)
+		if err == nil {
+			return exitStatus
+		}
+		if time.Now().After(deadline) {
+			klog.InfoS("Exit status of container is not recorded", "containerID", id, "err", err)
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// stopProcess sends SIGTERM to the process of the container, waits timeout
+// for the monitor to record its exit and sends SIGKILL if it is still
+// running. Socker normally exits with the container process and the monitor
+// records the exit status of the container, the socker parent is only killed
+// when it is still alive after that, the monitor then records its status.
+func stopProcess(id, pidStr string, timeout time.Duration) (*monitor.ExitStatus, error) {
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil {
+		return nil, err
+	}
+	// Look up the parent first, it is reparented once the container exits.
+	parent := sockerParent(pidStr)
+	if err = unix.Kill(pid, unix.SIGTERM); err != nil && err != unix.ESRCH {
+		return nil, err
+	}
+	if exitStatus := waitExitStatus(id, timeout); exitStatus != nil {
+		return exitStatus, nil
+	}
+	klog.InfoS("Container did not exit in time, killing it", "containerID", id, "timeout", timeout)
+	if err = unix.Kill(pid, unix.SIGKILL); err != nil && err != unix.ESRCH {
+		return nil, err
+	}
+	if exitStatus := waitExitStatus(id, containerExitWaitTimeout); exitStatus != nil {
+		return exitStatus, nil
+	}
+	if parent == 0 || !isSocker(parent) {
+		return nil, nil
+	}
+	klog.InfoS("Socker did not exit with the container, killing it", "containerID", id, "pid", parent)
+	if err = unix.Kill(parent, unix.SIGKILL); err != nil && err != unix.ESRCH {
+		return nil, err
+	}
+	return waitExitStatus(id, containerExitWaitTimeout), nil
+}
+
+// sockerParent returns the pid of the socker process running the container
+// process, or zero.
+func sockerParent(pid string) int {
+	ppidStr, err := util.ParentPid(pid)
+	if err != nil {
+		return 0
+	}
+	ppid, err := strconv.Atoi(ppidStr)
+	if err != nil || !isSocker(ppid) {
+		return 0
+	}
+	return ppid
+}
+
+// isSocker tells whether the process with the pid is socker, the pid may
+// have been reused by another process.
+func isSocker(pid int) bool {
+	comm, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	return err == nil && strings.TrimSpace(string(comm)) == "socker"
 }
 
 func (ss *sobeyService) RemoveContainer(ctx context.Context, req *runtimeapi.RemoveContainerRequest) (*runtimeapi.RemoveContainerResponse, error) {
@@ -491,6 +648,16 @@ func (ss *sobeyService) RemoveContainer(ctx context.Context, req *runtimeapi.Rem
 	if err != nil {
 		fmt.Printf("remove path file err, path: %s, err: %v", containerInfo.Path, err)
 	}
+	rotatedLogs, err := monitor.RotatedLogs(containerInfo.Path)
+	if err != nil {
+		fmt.Printf("list rotated log files err, path: %s, err: %v", containerInfo.Path, err)
+	}
+	for _, rotatedLog := range rotatedLogs {
+		err = ss.os.Remove(rotatedLog)
+		if err != nil {
+			fmt.Printf("remove path file err, path: %s, err: %v", rotatedLog, err)
+		}
+	}
 	err = ss.dbService.Delete(util.BuildContainerID(req.ContainerId))
 	if err != nil {
 		return nil, err
@@ -564,11 +731,14 @@ func (ss *sobeyService) ContainerStatus(ctx context.Context, req *runtimeapi.Con
 	if err != nil {
 		return nil, err
 	}
+	if exitErr := ss.updateContainerExit(&containerInfo); exitErr != nil {
+		klog.ErrorS(exitErr, "Failed to update exit status of container", "containerID", containerInfo.ID)
+	}
 	// Parse the timestamps.
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse timestamp for container %q: %v", containerInfo.ID, err)
 	}
-	imageID := util.ToPullableImageID(containerInfo.Image, true)
+	imageID := ss.imageRef(containerInfo.Image)
 
 	metadata, err := util.ParseContainerName(containerInfo.Name)
 	if err != nil {
@@ -579,35 +749,154 @@ func (ss *sobeyService) ContainerStatus(ctx context.Context, req *runtimeapi.Con
 
 	imageName := containerInfo.Image
 
-	mounts := make([]*runtimeapi.Mount, 0, 1)
-	mounts = append(mounts, &runtimeapi.Mount{
-		ContainerPath: fmt.Sprintf("/tmp/path/%s", imageName),
-		HostPath:      "sobey",
-		Readonly:      false,
-	})
+	mounts := containerMounts(&containerInfo)
+	var reason, message string
+	if containerInfo.State == runtimeapi.ContainerState_CONTAINER_EXITED {
+		reason = terminationReason(&containerInfo)
+		message = terminationMessage(&containerInfo, annotations)
+	}
 	containerStatus := &runtimeapi.ContainerStatus{
 		Id:          containerInfo.ID,
 		Metadata:    metadata,
 		Image:       &runtimeapi.ImageSpec{Image: imageName},
 		ImageRef:    imageID,
 		Mounts:      mounts,
-		ExitCode:    0,
+		ExitCode:    containerInfo.ExitCode,
 		State:       containerInfo.State,
 		CreatedAt:   containerInfo.CreateAt,
 		StartedAt:   containerInfo.StartedAt,
 		FinishedAt:  containerInfo.FinishedAt,
-		Reason:      "",
-		Message:     "",
+		Reason:      reason,
+		Message:     message,
 		Labels:      labels,
 		Annotations: annotations,
 		LogPath:     containerInfo.Labels[common.ContainerLogPathLabelKey],
 	}
-	return &runtimeapi.ContainerStatusResponse{Status: containerStatus}, nil
+	if !req.Verbose {
+		return &runtimeapi.ContainerStatusResponse{Status: containerStatus}, nil
+	}
+	info, err := containerVerboseInfo(&containerInfo, res)
+	if err != nil {
+		return nil, err
+	}
+	return &runtimeapi.ContainerStatusResponse{Status: containerStatus, Info: info}, nil
 }
 func (ss *sobeyService) UpdateContainerResources(ctx context.Context, req *runtimeapi.UpdateContainerResourcesRequest) (*runtimeapi.UpdateContainerResourcesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateContainerResources not implemented")
+	resources := req.GetLinux()
+	if resources == nil {
+		return nil, fmt.Errorf("linux resources are nil for container %q", req.ContainerId)
+	}
+	containerInfo, err := ss.getSobeyContainer(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+	err = ss.updateContainerExit(containerInfo)
+	if err != nil {
+		return nil, err
+	}
+	if containerInfo.State == runtimeapi.ContainerState_CONTAINER_EXITED {
+		return nil, fmt.Errorf("container %q is already exited", req.ContainerId)
+	}
+
+	if containerInfo.ContainerConfig.Linux == nil {
+		containerInfo.ContainerConfig.Linux = &runtimeapi.LinuxContainerConfig{}
+	}
+	resources = mergeResources(containerInfo.ContainerConfig.Linux.Resources, resources)
+
+	// Apply the resources to the live cgroup first, a rejected update leaves
+	// the record and the socker config untouched.
+	if containerInfo.State == runtimeapi.ContainerState_CONTAINER_RUNNING {
+		cgroup, err := cgroups.Load(containerInfo.Pid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load cgroup of container %q: %v", containerInfo.ID, err)
+		}
+		resource := toSockerResource(resources)
+		err = cgroup.Update(&resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update cgroup of container %q: %v", containerInfo.ID, err)
+		}
+	}
+
+	containerInfo.ContainerConfig.Linux.Resources = resources
+	err = updateConfFile(containerInfo.ID, resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update socker config of container %q: %v", containerInfo.ID, err)
+	}
+	bytes, err := json.Marshal(containerInfo)
+	if err != nil {
+		return nil, err
+	}
+	err = ss.dbService.PutWithPrefix(common.ContainerIDPrefix, containerInfo.ID, string(bytes))
+	if err != nil {
+		return nil, err
+	}
+	return &runtimeapi.UpdateContainerResourcesResponse{}, nil
+}
+
+// mergeResources returns the resources of a container after an update. Like
+// containerd, the fields the update leaves zero keep their current value,
+// the kubelet CPU manager for example only sends the cpuset.
+func mergeResources(current, update *runtimeapi.LinuxContainerResources) *runtimeapi.LinuxContainerResources {
+	merged := &runtimeapi.LinuxContainerResources{}
+	if current != nil {
+		*merged = *current
+		merged.HugepageLimits = append([]*runtimeapi.HugepageLimit(nil), current.HugepageLimits...)
+		merged.Unified = nil
+		for key, value := range current.Unified {
+			if merged.Unified == nil {
+				merged.Unified = make(map[string]string)
+			}
+			merged.Unified[key] = value
+		}
+	}
+	if update.CpuPeriod != 0 {
+		merged.CpuPeriod = update.CpuPeriod
+	}
+	if update.CpuQuota != 0 {
+		merged.CpuQuota = update.CpuQuota
+	}
+	if update.CpuShares != 0 {
+		merged.CpuShares = update.CpuShares
+	}
+	if update.MemoryLimitInBytes != 0 {
+		merged.MemoryLimitInBytes = update.MemoryLimitInBytes
+	}
+	if update.OomScoreAdj != 0 {
+		merged.OomScoreAdj = update.OomScoreAdj
+	}
+	if update.CpusetCpus != "" {
+		merged.CpusetCpus = update.CpusetCpus
+	}
+	if update.CpusetMems != "" {
+		merged.CpusetMems = update.CpusetMems
+	}
+	if len(update.HugepageLimits) != 0 {
+		merged.HugepageLimits = update.HugepageLimits
+	}
+	for key, value := range update.Unified {
+		if merged.Unified == nil {
+			merged.Unified = make(map[string]string)
+		}
+		merged.Unified[key] = value
+	}
+	if update.MemorySwapLimitInBytes != 0 {
+		merged.MemorySwapLimitInBytes = update.MemorySwapLimitInBytes
+	}
+	return merged
 }
 
 func (ss *sobeyService) ContainerStats(ctx context.Context, req *runtimeapi.ContainerStatsRequest) (*runtimeapi.ContainerStatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ContainerStats not implemented")
+	containerInfo, err := ss.getSobeyContainer(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+	err = ss.updateContainerExit(containerInfo)
+	if err != nil {
+		klog.ErrorS(err, "Failed to update exit status of container", "containerID", containerInfo.ID)
+	}
+	containerStats, err := ss.getContainerStats(containerInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &runtimeapi.ContainerStatsResponse{Stats: containerStats}, nil
 }
@@ -0,0 +1,45 @@
+package src
+
+import (
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"testing"
+)
+
+func TestMergeResources(t *testing.T) {
+	current := &runtimeapi.LinuxContainerResources{
+		CpuPeriod:              100000,
+		CpuQuota:               50000,
+		CpuShares:              512,
+		MemoryLimitInBytes:     256 << 20,
+		MemorySwapLimitInBytes: 512 << 20,
+		CpusetCpus:             "0-3",
+		Unified:                map[string]string{"pids.max": "64"},
+	}
+	// The kubelet CPU manager only sends the cpuset.
+	merged := mergeResources(current, &runtimeapi.LinuxContainerResources{CpusetCpus: "2"})
+	expected := *current
+	expected.CpusetCpus = "2"
+	if merged.CpuPeriod != expected.CpuPeriod || merged.CpuQuota != expected.CpuQuota ||
+		merged.CpuShares != expected.CpuShares || merged.MemoryLimitInBytes != expected.MemoryLimitInBytes ||
+		merged.MemorySwapLimitInBytes != expected.MemorySwapLimitInBytes || merged.CpusetCpus != expected.CpusetCpus ||
+		merged.Unified["pids.max"] != "64" {
+		t.Fatalf("unexpected merged resources %+v", merged)
+	}
+	if current.CpusetCpus != "0-3" {
+		t.Fatal("current resources are modified")
+	}
+
+	merged = mergeResources(merged, &runtimeapi.LinuxContainerResources{
+		MemoryLimitInBytes: 128 << 20,
+		Unified:            map[string]string{"memory.high": "100M"},
+	})
+	if merged.MemoryLimitInBytes != 128<<20 || merged.CpuShares != 512 || merged.CpusetCpus != "2" ||
+		merged.Unified["pids.max"] != "64" || merged.Unified["memory.high"] != "100M" {
+		t.Fatalf("unexpected merged resources %+v", merged)
+	}
+
+	merged = mergeResources(nil, &runtimeapi.LinuxContainerResources{CpusetCpus: "1"})
+	if merged.CpusetCpus != "1" || merged.MemoryLimitInBytes != 0 {
+		t.Fatalf("unexpected merged resources %+v", merged)
+	}
+}
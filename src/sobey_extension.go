@@ -0,0 +1,156 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/klog/v2"
+	sobeyapi "sobey-runtime/api/v1"
+	util "sobey-runtime/utils"
+	"time"
+)
+
+const (
+	// Name of the interface the CNI plugin sets up in the pod network namespace.
+	defaultPodInterfaceName = "eth0"
+	loopbackInterfaceName   = "lo"
+)
+
+func (ss *sobeyService) SobeyPodSandboxStats(ctx context.Context, req *sobeyapi.SobeyPodSandboxStatsRequest) (*sobeyapi.SobeyPodSandboxStatsResponse, error) {
+	sandboxInfo, err := ss.getSobeySandbox(req.PodSandboxId)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := ss.getPodSandboxStats(ctx, sandboxInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &sobeyapi.SobeyPodSandboxStatsResponse{Stats: stats}, nil
+}
+
+func (ss *sobeyService) ListSobeyPodSandboxStats(ctx context.Context, req *sobeyapi.ListSobeyPodSandboxStatsRequest) (*sobeyapi.ListSobeyPodSandboxStatsResponse, error) {
+	filter := &runtimeapi.PodSandboxFilter{
+		State: &runtimeapi.PodSandboxStateValue{State: runtimeapi.PodSandboxState_SANDBOX_READY},
+	}
+	if req.GetFilter() != nil {
+		filter.Id = req.Filter.Id
+		filter.LabelSelector = req.Filter.LabelSelector
+	}
+	listResp, err := ss.ListPodSandbox(ctx, &runtimeapi.ListPodSandboxRequest{Filter: filter})
+	if err != nil {
+		return nil, err
+	}
+	var stats []*sobeyapi.PodSandboxStats
+	for _, item := range listResp.Items {
+		sandboxInfo, err := ss.getSobeySandbox(item.Id)
+		if err != nil {
+			klog.InfoS("Failed to get sandbox for stats", "podSandboxID", item.Id, "err", err)
+			continue
+		}
+		sandboxStats, err := ss.getPodSandboxStats(ctx, sandboxInfo)
+		if err != nil {
+			klog.ErrorS(err, "Failed to get pod sandbox stats", "podSandboxID", item.Id)
+			continue
+		}
+		stats = append(stats, sandboxStats)
+	}
+	return &sobeyapi.ListSobeyPodSandboxStatsResponse{Stats: stats}, nil
+}
+
+// getPodSandboxStats reads the network counters from the namespace of the
+// pause process, and sums the cpu and memory usage of the running containers.
+func (ss *sobeyService) getPodSandboxStats(ctx context.Context, sandboxInfo *SobeySandbox) (*sobeyapi.PodSandboxStats, error) {
+	stats := &sobeyapi.PodSandboxStats{
+		Attributes: &sobeyapi.PodSandboxAttributes{Id: sandboxInfo.ID},
+		Timestamp:  time.Now().UnixNano(),
+		Cpu:        &sobeyapi.CpuUsage{},
+		Memory:     &sobeyapi.MemoryUsage{},
+	}
+	if sandboxInfo.Config != nil {
+		if metadata := sandboxInfo.Config.Metadata; metadata != nil {
+			stats.Attributes.Name = metadata.Name
+			stats.Attributes.Namespace = metadata.Namespace
+			stats.Attributes.Uid = metadata.Uid
+		}
+		stats.Attributes.Labels = sandboxInfo.Config.Labels
+		stats.Attributes.Annotations = sandboxInfo.Config.Annotations
+	}
+
+	if sandboxInfo.State == runtimeapi.PodSandboxState_SANDBOX_READY {
+		interfaces, err := util.ReadNetDev(sandboxInfo.Pid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read network stats of sandbox %q: %v", sandboxInfo.ID, err)
+		}
+		stats.Network = toNetworkUsage(interfaces)
+	}
+
+	listResp, err := ss.ListContainers(ctx, &runtimeapi.ListContainersRequest{
+		Filter: &runtimeapi.ContainerFilter{
+			PodSandboxId: sandboxInfo.ID,
+			State:        &runtimeapi.ContainerStateValue{State: runtimeapi.ContainerState_CONTAINER_RUNNING},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range listResp.Containers {
+		containerInfo, err := ss.getSobeyContainer(container.Id)
+		if err != nil {
+			klog.InfoS("Failed to get container for stats", "containerID", container.Id, "err", err)
+			continue
+		}
+		cgroupStats, err := getCgroupStats(containerInfo.Pid)
+		if err != nil {
+			klog.ErrorS(err, "Failed to get cgroup stats of container", "containerID", containerInfo.ID)
+			continue
+		}
+		containerStats := &sobeyapi.ContainerStats{
+			Id:  containerInfo.ID,
+			Cpu: &sobeyapi.CpuUsage{UsageCoreNanoSeconds: cgroupStats.CpuUsageNanos},
+			Memory: &sobeyapi.MemoryUsage{
+				WorkingSetBytes: cgroupStats.WorkingSetBytes,
+				UsageBytes:      cgroupStats.MemoryUsageBytes,
+				RssBytes:        cgroupStats.RssBytes,
+				PageFaults:      cgroupStats.PageFaults,
+				MajorPageFaults: cgroupStats.MajorPageFaults,
+			},
+		}
+		if container.Metadata != nil {
+			containerStats.Name = container.Metadata.Name
+		}
+		stats.Containers = append(stats.Containers, containerStats)
+
+		stats.Cpu.UsageCoreNanoSeconds += containerStats.Cpu.UsageCoreNanoSeconds
+		stats.Memory.WorkingSetBytes += containerStats.Memory.WorkingSetBytes
+		stats.Memory.UsageBytes += containerStats.Memory.UsageBytes
+		stats.Memory.RssBytes += containerStats.Memory.RssBytes
+		stats.Memory.PageFaults += containerStats.Memory.PageFaults
+		stats.Memory.MajorPageFaults += containerStats.Memory.MajorPageFaults
+	}
+	return stats, nil
+}
+
+func toNetworkUsage(interfaces []util.InterfaceStats) *sobeyapi.NetworkUsage {
+	network := &sobeyapi.NetworkUsage{}
+	for _, iface := range interfaces {
+		if iface.Name == loopbackInterfaceName {
+			continue
+		}
+		usage := &sobeyapi.NetworkInterfaceUsage{
+			Name:      iface.Name,
+			RxBytes:   iface.RxBytes,
+			RxPackets: iface.RxPackets,
+			RxErrors:  iface.RxErrors,
+			RxDropped: iface.RxDropped,
+			TxBytes:   iface.TxBytes,
+			TxPackets: iface.TxPackets,
+			TxErrors:  iface.TxErrors,
+			TxDropped: iface.TxDropped,
+		}
+		if iface.Name == defaultPodInterfaceName {
+			network.DefaultInterface = usage
+		}
+		network.Interfaces = append(network.Interfaces, usage)
+	}
+	return network
+}
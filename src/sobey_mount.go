@@ -0,0 +1,175 @@
+package src
+
+import (
+	"fmt"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/klog/v2"
+	"os"
+	"path/filepath"
+	util "sobey-runtime/utils"
+	"strings"
+)
+
+var (
+	// Filesystems socker mounts to set up the container itself, they are not
+	// volumes of the container.
+	systemMountTypes = map[string]struct{}{
+		"proc":     {},
+		"sysfs":    {},
+		"devpts":   {},
+		"devtmpfs": {},
+		"mqueue":   {},
+		"cgroup":   {},
+		"cgroup2":  {},
+	}
+	systemMountDirs = []string{"/proc", "/sys", "/dev"}
+)
+
+// containerMounts returns the mounts of the container. The mounts requested
+// in the CRI config come first with their flags, followed by the mounts
+// socker added, from the socker config and from the mount table of the
+// running container.
+func containerMounts(containerInfo *SobeyContainer) []*runtimeapi.Mount {
+	var mounts []*runtimeapi.Mount
+	known := make(map[string]struct{})
+	add := func(mount *runtimeapi.Mount) {
+		containerPath := filepath.Clean(mount.ContainerPath)
+		if _, ok := known[containerPath]; ok {
+			return
+		}
+		known[containerPath] = struct{}{}
+		mounts = append(mounts, mount)
+	}
+
+	for _, mount := range containerInfo.ContainerConfig.GetMounts() {
+		add(&runtimeapi.Mount{
+			ContainerPath:  mount.ContainerPath,
+			HostPath:       mount.HostPath,
+			Readonly:       mount.Readonly,
+			SelinuxRelabel: mount.SelinuxRelabel,
+			Propagation:    mount.Propagation,
+		})
+	}
+	if containerInfo.State == runtimeapi.ContainerState_CONTAINER_RUNNING {
+		liveMounts, err := readLiveMounts(containerPid(containerInfo))
+		if err != nil {
+			klog.InfoS("Failed to read mounts of container", "containerID", containerInfo.ID, "err", err)
+		}
+		for _, mount := range liveMounts {
+			add(mount)
+		}
+	}
+	conf, err := readConfFile(containerInfo.ID)
+	if err != nil && !os.IsNotExist(err) {
+		klog.InfoS("Failed to read socker config of container", "containerID", containerInfo.ID, "err", err)
+	}
+	if conf != nil {
+		for _, mount := range conf.Mount {
+			add(&runtimeapi.Mount{
+				ContainerPath: mount.ContainerPath,
+				HostPath:      mount.HostPath,
+			})
+		}
+	}
+	return mounts
+}
+
+// readLiveMounts reads the bind mounts of the running container from its
+// mount table, and resolves their host paths from the mount table of the
+// runtime.
+func readLiveMounts(pid string) ([]*runtimeapi.Mount, error) {
+	root, err := os.Readlink(fmt.Sprintf("/proc/%s/root", pid))
+	if err != nil {
+		return nil, err
+	}
+	containerMountInfos, err := util.ReadMountInfo(pid)
+	if err != nil {
+		return nil, err
+	}
+	hostMountInfos, err := util.ReadMountInfo("self")
+	if err != nil {
+		return nil, err
+	}
+	var mounts []*runtimeapi.Mount
+	for i := range containerMountInfos {
+		mountInfo := &containerMountInfos[i]
+		if _, ok := systemMountTypes[mountInfo.FSType]; ok {
+			continue
+		}
+		containerPath, ok := pathInRoot(root, mountInfo.MountPoint)
+		if !ok || containerPath == "/" || isSystemMountPath(containerPath) {
+			continue
+		}
+		hostPath := resolveHostPath(root, mountInfo, hostMountInfos)
+		if len(hostPath) == 0 {
+			continue
+		}
+		propagation := runtimeapi.MountPropagation_PROPAGATION_PRIVATE
+		if mountInfo.HasOptional("shared:") {
+			propagation = runtimeapi.MountPropagation_PROPAGATION_BIDIRECTIONAL
+		} else if mountInfo.HasOptional("master:") {
+			propagation = runtimeapi.MountPropagation_PROPAGATION_HOST_TO_CONTAINER
+		}
+		mounts = append(mounts, &runtimeapi.Mount{
+			ContainerPath: containerPath,
+			HostPath:      hostPath,
+			Readonly:      mountInfo.ReadOnly(),
+			Propagation:   propagation,
+		})
+	}
+	return mounts, nil
+}
+
+// pathInRoot turns a mount point seen by the container into the path inside
+// the container. A container which is only chrooted sees the mount points
+// of the host, and only the ones under its root belong to it.
+func pathInRoot(root, mountPoint string) (string, bool) {
+	if root == "/" {
+		return mountPoint, true
+	}
+	if mountPoint == root {
+		return "/", true
+	}
+	if !strings.HasPrefix(mountPoint, root+"/") {
+		return "", false
+	}
+	return strings.TrimPrefix(mountPoint, root), true
+}
+
+func isSystemMountPath(path string) bool {
+	for _, dir := range systemMountDirs {
+		if path == dir || strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveHostPath finds the host mount of the filesystem the mount is bound
+// from, preferring the one mounting the deepest directory of it.
+func resolveHostPath(root string, mountInfo *util.MountInfo, hostMountInfos []util.MountInfo) string {
+	var source *util.MountInfo
+	for i := range hostMountInfos {
+		hostMountInfo := &hostMountInfos[i]
+		if hostMountInfo.Device != mountInfo.Device {
+			continue
+		}
+		if _, inRoot := pathInRoot(root, hostMountInfo.MountPoint); root != "/" && inRoot {
+			continue
+		}
+		if hostMountInfo.Root != "/" && mountInfo.Root != hostMountInfo.Root &&
+			!strings.HasPrefix(mountInfo.Root, hostMountInfo.Root+"/") {
+			continue
+		}
+		if source == nil || len(hostMountInfo.Root) > len(source.Root) {
+			source = hostMountInfo
+		}
+	}
+	if source == nil {
+		return ""
+	}
+	if source.Root == "/" {
+		return filepath.Join(source.MountPoint, mountInfo.Root)
+	}
+	return filepath.Join(source.MountPoint, strings.TrimPrefix(mountInfo.Root, source.Root))
+}
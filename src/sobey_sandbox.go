@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"sobey-runtime/common"
+	"sobey-runtime/filter"
 	util "sobey-runtime/utils"
 	"strconv"
 	"strings"
@@ -30,6 +31,23 @@ type SobeySandbox struct {
 	CreateTime int64                      `json:"createTime"`
 }
 
+// getSobeySandbox loads the record of a sandbox from etcd.
+func (ss *sobeyService) getSobeySandbox(podSandboxID string) (*SobeySandbox, error) {
+	sandboxInfoStr, err := ss.dbService.Get(util.BuildSandboxID(podSandboxID))
+	if err != nil {
+		return nil, err
+	}
+	if len(sandboxInfoStr) == 0 {
+		return nil, fmt.Errorf("Sandbox is not exist, sandboxID: %s ", podSandboxID)
+	}
+	sandboxInfo := new(SobeySandbox)
+	err = json.Unmarshal([]byte(sandboxInfoStr), &sandboxInfo)
+	if err != nil {
+		return nil, err
+	}
+	return sandboxInfo, nil
+}
+
 // Returns whether the sandbox network is ready, and whether the sandbox is known
 func (ss *sobeyService) getNetworkReady(podSandboxID string) (bool, bool) {
 	ss.networkReadyLock.Lock()
@@ -179,8 +197,7 @@ func (ss *sobeyService) setupNet(id string, config *runtimeapi.PodSandboxConfig)
 		return "", errList[0]
 	}
 	res := new(CNICache)
-	path := fmt.Sprintf("/var/lib/cni/cache/results/cbr0-%s-eth0", id)
-	buffer, err := ioutil.ReadFile(path)
+	buffer, err := ioutil.ReadFile(cniResultPath(id))
 	if err != nil {
 		return "", err
 	}
@@ -195,6 +212,12 @@ func (ss *sobeyService) setupNet(id string, config *runtimeapi.PodSandboxConfig)
 	return address, err
 }
 
+// cniResultPath is where the CNI plugin caches the result of setting up the
+// network of the sandbox.
+func cniResultPath(id string) string {
+	return fmt.Sprintf("/var/lib/cni/cache/results/cbr0-%s-eth0", id)
+}
+
 type CNICache struct {
 	Kind        string        `json:"kind"`
 	ContainerId string        `json:"containerId"`
@@ -394,10 +417,16 @@ func (ss *sobeyService) PodSandboxStatus(ctx context.Context, req *runtimeapi.Po
 		})
 	}
 	sandboxStatus.Network.AdditionalIps = additionalPodIPs
-	return &runtimeapi.PodSandboxStatusResponse{Status: sandboxStatus}, nil
+	if !req.Verbose {
+		return &runtimeapi.PodSandboxStatusResponse{Status: sandboxStatus}, nil
+	}
+	info, err := ss.sandboxVerboseInfo(sandbox)
+	if err != nil {
+		return nil, err
+	}
+	return &runtimeapi.PodSandboxStatusResponse{Status: sandboxStatus, Info: info}, nil
 }
 func (ss *sobeyService) ListPodSandbox(ctx context.Context, req *runtimeapi.ListPodSandboxRequest) (*runtimeapi.ListPodSandboxResponse, error) {
-	filter := req.GetFilter()
 	results, err := ss.dbService.GetByPrefix(common.SandboxIDPrefix)
 	if err != nil {
 		return &runtimeapi.ListPodSandboxResponse{}, err
@@ -424,47 +453,7 @@ func (ss *sobeyService) ListPodSandbox(ctx context.Context, req *runtimeapi.List
 			})
 		}
 	}
-	if len(items) == 0 {
-		return &runtimeapi.ListPodSandboxResponse{Items: items}, nil
-	}
-	return &runtimeapi.ListPodSandboxResponse{Items: filterPodSandbox(filter, items)}, nil
-}
-
-func filterPodSandbox(filter *runtimeapi.PodSandboxFilter, podSandboxes []*runtimeapi.PodSandbox) []*runtimeapi.PodSandbox {
-	if filter == nil {
-		return podSandboxes
-	}
-	var idFilterItems []*runtimeapi.PodSandbox
-	if len(filter.Id) != 0 {
-		for _, item := range podSandboxes {
-			if strings.EqualFold(filter.Id, item.Id) {
-				idFilterItems = append(idFilterItems, item)
-			}
-		}
-	} else {
-		idFilterItems = podSandboxes
-	}
-	var stateFilterItems []*runtimeapi.PodSandbox
-	if filter.State != nil {
-		for _, item := range idFilterItems {
-			if item.State == filter.State.State {
-				stateFilterItems = append(stateFilterItems, item)
-			}
-		}
-	} else {
-		stateFilterItems = idFilterItems
-	}
-	var uidFilterItems []*runtimeapi.PodSandbox
-	if len(filter.LabelSelector[common.KubernetesPodUIDLabel]) != 0 {
-		for _, item := range stateFilterItems {
-			if strings.EqualFold(filter.LabelSelector[common.KubernetesPodUIDLabel], item.Labels[common.KubernetesPodUIDLabel]) {
-				uidFilterItems = append(uidFilterItems, item)
-			}
-		}
-	} else {
-		uidFilterItems = stateFilterItems
-	}
-	return uidFilterItems
+	return &runtimeapi.ListPodSandboxResponse{Items: filter.PodSandboxes(req.GetFilter(), items)}, nil
 }
 
 // networkNamespaceMode returns the network runtimeapi.NamespaceMode for this container.
@@ -3,22 +3,29 @@ package src
 import (
 	"fmt"
 	v1 "k8s.io/api/core/v1"
+	runtimeapiv1 "k8s.io/cri-api/pkg/apis/runtime/v1"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 	"k8s.io/klog/v2"
 	kubeletconfig "k8s.io/kubernetes/pkg/kubelet/apis/config"
 	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager"
+	"k8s.io/kubernetes/pkg/kubelet/cri/streaming"
 	"k8s.io/kubernetes/pkg/kubelet/dockershim"
 	"k8s.io/kubernetes/pkg/kubelet/dockershim/network"
 	"k8s.io/kubernetes/pkg/kubelet/dockershim/network/cni"
 	"k8s.io/kubernetes/pkg/kubelet/dockershim/network/hostport"
 	"k8s.io/kubernetes/pkg/kubelet/dockershim/network/kubenet"
 	"net/http"
+	"os"
 	"path/filepath"
+	sobeyapi "sobey-runtime/api/v1"
+	"sobey-runtime/common"
 	"sobey-runtime/config"
 	"sobey-runtime/etcd"
+	"sobey-runtime/image"
 	util "sobey-runtime/utils"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
@@ -30,6 +37,7 @@ const (
 type CRIService interface {
 	runtimeapi.RuntimeServiceServer
 	runtimeapi.ImageServiceServer
+	sobeyapi.ExtensionServiceServer
 	SobeyCniInterface
 	Start() error
 }
@@ -39,6 +47,12 @@ type SobeyService interface {
 	http.Handler
 }
 
+// V1CRIService is the CRI v1 view of a CRIService, see NewSobeyV1Service.
+type V1CRIService interface {
+	runtimeapiv1.RuntimeServiceServer
+	runtimeapiv1.ImageServiceServer
+}
+
 type namespaceGetter struct {
 	ss *sobeyService
 }
@@ -72,14 +86,27 @@ type sobeyService struct {
 
 	checkpointManager checkpointmanager.CheckpointManager
 
+	// For serving streaming calls.
+	streamingRuntime *streamingRuntime
+	streamingServer  streaming.Server
+
 	// etcd
 	dbService *etcd.DBService
 
 	// ipRange
 	ipRange string
 
-	// repo
-	repo string
+	// images
+	imageStore   *image.Store
+	imageSources []*image.Source
+
+	// image pre-warming, nil when disabled
+	prewarmer *prewarmer
+
+	// image garbage collection
+	imageGCPolicy image.GCPolicy
+	imageGCPeriod time.Duration
+	imageGCLock   sync.Mutex
 
 	// server
 	host             string
@@ -88,9 +115,27 @@ type sobeyService struct {
 	healthyApiUrl    string
 	listServerApiUrl string
 	polling          []int
+
+	// container log
+	logMaxSize  int64
+	logMaxFiles int
+}
+
+// Options are the config sections the sobey service is created with, every
+// section but Server is optional.
+type Options struct {
+	Server       *config.Server
+	Streaming    *config.Streaming
+	ContainerLog *config.ContainerLog
+	ImageGC      *config.ImageGC
+	Credentials  []config.Credential
+	ImageSources []config.ImageSource
+	Prewarm      *config.Prewarm
 }
 
-func NewSobeyService(serverConf *config.Server, pluginSettings *dockershim.NetworkPluginSettings) (SobeyService, error) {
+func NewSobeyService(opts *Options, pluginSettings *dockershim.NetworkPluginSettings) (SobeyService, error) {
+	serverConf := opts.Server
+	imageSourcesConf := opts.ImageSources
 	checkpointManager, err := checkpointmanager.NewCheckpointManager(filepath.Join(sobeyshimRootDir, "sandbox"))
 	if err != nil {
 		return nil, err
@@ -104,7 +149,10 @@ func NewSobeyService(serverConf *config.Server, pluginSettings *dockershim.Netwo
 
 		ipRange: serverConf.IpRange,
 
-		repo: serverConf.Repo,
+		imageStore: image.NewStore(common.SockerImagesPath, common.SockerTempPath, common.SockerImageIndexPath),
+		// Only the manual collection of all the unused images works
+		// without the config.
+		imageGCPolicy: image.GCPolicy{HighThresholdPercent: 100, LowThresholdPercent: 100},
 
 		checkpointManager: checkpointManager,
 
@@ -115,6 +163,71 @@ func NewSobeyService(serverConf *config.Server, pluginSettings *dockershim.Netwo
 		listServerApiUrl: fmt.Sprintf("%s%s", serverConf.Host, serverConf.Apis.List),
 		polling:          serverConf.Polling,
 	}
+	ss.streamingRuntime = &streamingRuntime{ss: ss}
+	if opts.ContainerLog != nil {
+		ss.logMaxSize = opts.ContainerLog.MaxSize
+		ss.logMaxFiles = opts.ContainerLog.MaxFiles
+	}
+	if len(imageSourcesConf) == 0 && serverConf.Repo != "" {
+		imageSourcesConf = []config.ImageSource{{Name: "repo", Url: serverConf.Repo}}
+	}
+	var repoCredentials []image.RepoCredential
+	for _, credential := range opts.Credentials {
+		repoCredentials = append(repoCredentials, image.RepoCredential{
+			Prefix: credential.Repo,
+			Credential: image.Credential{
+				Username: credential.Username,
+				Password: credential.Password,
+				Token:    credential.Token,
+			},
+		})
+	}
+	for _, sourceConf := range imageSourcesConf {
+		source, err := image.NewSource(sourceConf.Name, sourceConf.Url, sourceConf.CaFile, sourceConf.Prefixes)
+		if err != nil {
+			return nil, err
+		}
+		source.Credentials = repoCredentials
+		source.Policy, err = verifyPolicy(sourceConf.Verify)
+		if err != nil {
+			return nil, fmt.Errorf("verify policy of image source %s is invalid: %v", sourceConf.Name, err)
+		}
+		ss.imageSources = append(ss.imageSources, source)
+	}
+	if prewarmConf := opts.Prewarm; prewarmConf != nil && (len(prewarmConf.Images) != 0 || prewarmConf.EtcdKey != "") {
+		ss.prewarmer = &prewarmer{
+			images:      prewarmConf.Images,
+			etcdKey:     prewarmConf.EtcdKey,
+			concurrency: prewarmConf.Concurrency,
+			period:      time.Duration(prewarmConf.Period) * time.Second,
+		}
+		if ss.prewarmer.concurrency <= 0 {
+			ss.prewarmer.concurrency = 1
+		}
+	}
+	if opts.ImageGC != nil {
+		ss.imageGCPolicy = image.GCPolicy{
+			HighThresholdPercent: opts.ImageGC.HighThresholdPercent,
+			LowThresholdPercent:  opts.ImageGC.LowThresholdPercent,
+		}
+		if err = ss.imageGCPolicy.Validate(); err != nil {
+			return nil, fmt.Errorf("image gc config is invalid: %v", err)
+		}
+		if opts.ImageGC.Period < 0 {
+			return nil, fmt.Errorf("image gc config is invalid: negative period %d", opts.ImageGC.Period)
+		}
+		ss.imageGCPeriod = time.Duration(opts.ImageGC.Period) * time.Second
+	}
+
+	// create streaming server if configured.
+	if opts.Streaming != nil {
+		streamingConfig := streaming.DefaultConfig
+		streamingConfig.Addr = opts.Streaming.Addr
+		ss.streamingServer, err = streaming.NewServer(streamingConfig, ss.streamingRuntime)
+		if err != nil {
+			return nil, err
+		}
+	}
 	// Determine the hairpin mode.
 	if err := effectiveHairpinMode(pluginSettings); err != nil {
 		// This is a non-recoverable error. Returning it up the callstack will just
@@ -140,11 +253,35 @@ func NewSobeyService(serverConf *config.Server, pluginSettings *dockershim.Netwo
 	return ss, nil
 }
 
+// Start initializes and starts components in sobeyService.
 func (ss *sobeyService) Start() error {
+	if err := util.CreateDirsIfDontExist(runtimeStateDirs); err != nil {
+		return err
+	}
+	if ss.streamingServer != nil {
+		go func() {
+			if err := ss.streamingServer.Start(true); err != nil {
+				klog.ErrorS(err, "Streaming server stopped unexpectedly")
+				os.Exit(1)
+			}
+		}()
+	}
+	if ss.imageGCPeriod > 0 {
+		go ss.imageGCLoop()
+	}
+	if ss.prewarmer != nil {
+		go ss.prewarmLoop()
+	}
 	return nil
 }
 
+// ServeHTTP serves the streaming requests of exec, attach and port forward.
 func (ss *sobeyService) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if ss.streamingServer != nil {
+		ss.streamingServer.ServeHTTP(writer, request)
+	} else {
+		http.NotFound(writer, request)
+	}
 }
 
 func (ss *sobeyService) GetNetNS(pid string) (string, error) {
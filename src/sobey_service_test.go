@@ -17,7 +17,7 @@ func TestSobeyService_InitIpRange(t *testing.T) {
 			"https://172.16.200.169:2379"},
 	}
 	_ = etcd.InitEtcd(etcdConf)
-	service, _ := NewSobeyService(&config.Server{
+	service, _ := NewSobeyService(&Options{Server: &config.Server{
 		Host: "http://172.16.200.112:9067",
 		Apis: config.ServerApi{
 			Run:     "/v1/server/run",
@@ -26,7 +26,7 @@ func TestSobeyService_InitIpRange(t *testing.T) {
 			List:    "/v1/server/list",
 		},
 		IpRange: "172.244.0.0/24",
-	}, nil)
+	}}, nil)
 	_ = service.InitIpRange()
 }
 
@@ -40,7 +40,7 @@ func TestSobeyService_PutReleasedIP(t *testing.T) {
 			"https://172.16.200.169:2379"},
 	}
 	_ = etcd.InitEtcd(etcdConf)
-	service, _ := NewSobeyService(&config.Server{
+	service, _ := NewSobeyService(&Options{Server: &config.Server{
 		Host: "http://172.16.200.112:9067",
 		Apis: config.ServerApi{
 			Run:     "/v1/server/run",
@@ -49,7 +49,7 @@ func TestSobeyService_PutReleasedIP(t *testing.T) {
 			List:    "/v1/server/list",
 		},
 		IpRange: "172.244.0.0/24",
-	}, nil)
+	}}, nil)
 	_ = service.PutReleasedIP("172.16.200.2")
 }
 
@@ -63,7 +63,7 @@ func TestSobeyService_NewSandboxIP(t *testing.T) {
 			"https://172.16.200.169:2379"},
 	}
 	_ = etcd.InitEtcd(etcdConf)
-	service, _ := NewSobeyService(&config.Server{
+	service, _ := NewSobeyService(&Options{Server: &config.Server{
 		Host: "http://172.16.200.112:9067",
 		Apis: config.ServerApi{
 			Run:     "/v1/server/run",
@@ -72,7 +72,7 @@ func TestSobeyService_NewSandboxIP(t *testing.T) {
 			List:    "/v1/server/list",
 		},
 		IpRange: "172.244.0.0/24",
-	}, nil)
+	}}, nil)
 	ip, _ := service.NewSandboxIP()
 	fmt.Println(ip)
 }
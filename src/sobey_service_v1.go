@@ -0,0 +1,474 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	runtimeapiv1 "k8s.io/cri-api/pkg/apis/runtime/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"reflect"
+)
+
+// criMessage is implemented by every generated request and response of both
+// CRI versions. v1 and v1alpha2 share the same wire format, so a message of one
+// version is converted to the other with a marshal/unmarshal round trip.
+type criMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal(dAtA []byte) error
+}
+
+// convertCRIMessage copies in into out through their protobuf encoding.
+func convertCRIMessage(in, out criMessage) error {
+	if reflect.ValueOf(in).IsNil() {
+		return nil
+	}
+	data, err := in.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal %T: %v", in, err)
+	}
+	if err = out.Unmarshal(data); err != nil {
+		return fmt.Errorf("failed to convert %T to %T: %v", in, out, err)
+	}
+	return nil
+}
+
+// sobeyV1Service serves the CRI v1 API on top of the v1alpha2 handlers of a
+// CRIService, so sandbox and container logic only lives in one place.
+type sobeyV1Service struct {
+	ss CRIService
+}
+
+func NewSobeyV1Service(ss CRIService) V1CRIService {
+	return &sobeyV1Service{ss: ss}
+}
+
+func (v1 *sobeyV1Service) Version(ctx context.Context, req *runtimeapiv1.VersionRequest) (*runtimeapiv1.VersionResponse, error) {
+	alphaReq := &runtimeapi.VersionRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.Version(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.VersionResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) RunPodSandbox(ctx context.Context, req *runtimeapiv1.RunPodSandboxRequest) (*runtimeapiv1.RunPodSandboxResponse, error) {
+	alphaReq := &runtimeapi.RunPodSandboxRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.RunPodSandbox(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.RunPodSandboxResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) StopPodSandbox(ctx context.Context, req *runtimeapiv1.StopPodSandboxRequest) (*runtimeapiv1.StopPodSandboxResponse, error) {
+	alphaReq := &runtimeapi.StopPodSandboxRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.StopPodSandbox(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.StopPodSandboxResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) RemovePodSandbox(ctx context.Context, req *runtimeapiv1.RemovePodSandboxRequest) (*runtimeapiv1.RemovePodSandboxResponse, error) {
+	alphaReq := &runtimeapi.RemovePodSandboxRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.RemovePodSandbox(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.RemovePodSandboxResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) PodSandboxStatus(ctx context.Context, req *runtimeapiv1.PodSandboxStatusRequest) (*runtimeapiv1.PodSandboxStatusResponse, error) {
+	alphaReq := &runtimeapi.PodSandboxStatusRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.PodSandboxStatus(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.PodSandboxStatusResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) ListPodSandbox(ctx context.Context, req *runtimeapiv1.ListPodSandboxRequest) (*runtimeapiv1.ListPodSandboxResponse, error) {
+	alphaReq := &runtimeapi.ListPodSandboxRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.ListPodSandbox(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ListPodSandboxResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) CreateContainer(ctx context.Context, req *runtimeapiv1.CreateContainerRequest) (*runtimeapiv1.CreateContainerResponse, error) {
+	alphaReq := &runtimeapi.CreateContainerRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.CreateContainer(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.CreateContainerResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) StartContainer(ctx context.Context, req *runtimeapiv1.StartContainerRequest) (*runtimeapiv1.StartContainerResponse, error) {
+	alphaReq := &runtimeapi.StartContainerRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.StartContainer(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.StartContainerResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) StopContainer(ctx context.Context, req *runtimeapiv1.StopContainerRequest) (*runtimeapiv1.StopContainerResponse, error) {
+	alphaReq := &runtimeapi.StopContainerRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.StopContainer(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.StopContainerResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) RemoveContainer(ctx context.Context, req *runtimeapiv1.RemoveContainerRequest) (*runtimeapiv1.RemoveContainerResponse, error) {
+	alphaReq := &runtimeapi.RemoveContainerRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.RemoveContainer(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.RemoveContainerResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) ListContainers(ctx context.Context, req *runtimeapiv1.ListContainersRequest) (*runtimeapiv1.ListContainersResponse, error) {
+	alphaReq := &runtimeapi.ListContainersRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.ListContainers(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ListContainersResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) ContainerStatus(ctx context.Context, req *runtimeapiv1.ContainerStatusRequest) (*runtimeapiv1.ContainerStatusResponse, error) {
+	alphaReq := &runtimeapi.ContainerStatusRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.ContainerStatus(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ContainerStatusResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) UpdateContainerResources(ctx context.Context, req *runtimeapiv1.UpdateContainerResourcesRequest) (*runtimeapiv1.UpdateContainerResourcesResponse, error) {
+	alphaReq := &runtimeapi.UpdateContainerResourcesRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.UpdateContainerResources(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.UpdateContainerResourcesResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) ReopenContainerLog(ctx context.Context, req *runtimeapiv1.ReopenContainerLogRequest) (*runtimeapiv1.ReopenContainerLogResponse, error) {
+	alphaReq := &runtimeapi.ReopenContainerLogRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.ReopenContainerLog(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ReopenContainerLogResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) ExecSync(ctx context.Context, req *runtimeapiv1.ExecSyncRequest) (*runtimeapiv1.ExecSyncResponse, error) {
+	alphaReq := &runtimeapi.ExecSyncRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.ExecSync(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ExecSyncResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) Exec(ctx context.Context, req *runtimeapiv1.ExecRequest) (*runtimeapiv1.ExecResponse, error) {
+	alphaReq := &runtimeapi.ExecRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.Exec(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ExecResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) Attach(ctx context.Context, req *runtimeapiv1.AttachRequest) (*runtimeapiv1.AttachResponse, error) {
+	alphaReq := &runtimeapi.AttachRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.Attach(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.AttachResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) PortForward(ctx context.Context, req *runtimeapiv1.PortForwardRequest) (*runtimeapiv1.PortForwardResponse, error) {
+	alphaReq := &runtimeapi.PortForwardRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.PortForward(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.PortForwardResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) ContainerStats(ctx context.Context, req *runtimeapiv1.ContainerStatsRequest) (*runtimeapiv1.ContainerStatsResponse, error) {
+	alphaReq := &runtimeapi.ContainerStatsRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.ContainerStats(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ContainerStatsResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) ListContainerStats(ctx context.Context, req *runtimeapiv1.ListContainerStatsRequest) (*runtimeapiv1.ListContainerStatsResponse, error) {
+	alphaReq := &runtimeapi.ListContainerStatsRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.ListContainerStats(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ListContainerStatsResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) UpdateRuntimeConfig(ctx context.Context, req *runtimeapiv1.UpdateRuntimeConfigRequest) (*runtimeapiv1.UpdateRuntimeConfigResponse, error) {
+	alphaReq := &runtimeapi.UpdateRuntimeConfigRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.UpdateRuntimeConfig(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.UpdateRuntimeConfigResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) Status(ctx context.Context, req *runtimeapiv1.StatusRequest) (*runtimeapiv1.StatusResponse, error) {
+	alphaReq := &runtimeapi.StatusRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.Status(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.StatusResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) ListImages(ctx context.Context, req *runtimeapiv1.ListImagesRequest) (*runtimeapiv1.ListImagesResponse, error) {
+	alphaReq := &runtimeapi.ListImagesRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.ListImages(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ListImagesResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) ImageStatus(ctx context.Context, req *runtimeapiv1.ImageStatusRequest) (*runtimeapiv1.ImageStatusResponse, error) {
+	alphaReq := &runtimeapi.ImageStatusRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.ImageStatus(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ImageStatusResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) PullImage(ctx context.Context, req *runtimeapiv1.PullImageRequest) (*runtimeapiv1.PullImageResponse, error) {
+	alphaReq := &runtimeapi.PullImageRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.PullImage(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.PullImageResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) RemoveImage(ctx context.Context, req *runtimeapiv1.RemoveImageRequest) (*runtimeapiv1.RemoveImageResponse, error) {
+	alphaReq := &runtimeapi.RemoveImageRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.RemoveImage(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.RemoveImageResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (v1 *sobeyV1Service) ImageFsInfo(ctx context.Context, req *runtimeapiv1.ImageFsInfoRequest) (*runtimeapiv1.ImageFsInfoResponse, error) {
+	alphaReq := &runtimeapi.ImageFsInfoRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		return nil, err
+	}
+	alphaResp, err := v1.ss.ImageFsInfo(ctx, alphaReq)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapiv1.ImageFsInfoResponse{}
+	if err = convertCRIMessage(alphaResp, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
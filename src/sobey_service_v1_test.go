@@ -0,0 +1,41 @@
+package src
+
+import (
+	runtimeapiv1 "k8s.io/cri-api/pkg/apis/runtime/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"testing"
+)
+
+func TestConvertCRIMessage(t *testing.T) {
+	req := &runtimeapiv1.CreateContainerRequest{
+		PodSandboxId: "sandbox_1",
+		Config: &runtimeapiv1.ContainerConfig{
+			Metadata: &runtimeapiv1.ContainerMetadata{Name: "app", Attempt: 2},
+			Image:    &runtimeapiv1.ImageSpec{Image: "demo/app.jar"},
+			Mounts: []*runtimeapiv1.Mount{{
+				ContainerPath: "/data",
+				HostPath:      "/mnt/data",
+				Readonly:      true,
+				Propagation:   runtimeapiv1.MountPropagation_PROPAGATION_HOST_TO_CONTAINER,
+			}},
+		},
+	}
+	alphaReq := &runtimeapi.CreateContainerRequest{}
+	if err := convertCRIMessage(req, alphaReq); err != nil {
+		t.Fatal(err)
+	}
+	if alphaReq.PodSandboxId != "sandbox_1" || alphaReq.Config.Metadata.Attempt != 2 ||
+		alphaReq.Config.Image.Image != "demo/app.jar" {
+		t.Fatalf("unexpected request after conversion: %+v", alphaReq)
+	}
+	mount := alphaReq.Config.Mounts[0]
+	if !mount.Readonly || mount.Propagation != runtimeapi.MountPropagation_PROPAGATION_HOST_TO_CONTAINER {
+		t.Fatalf("unexpected mount after conversion: %+v", mount)
+	}
+
+	var nilResp *runtimeapi.VersionResponse
+	resp := &runtimeapiv1.VersionResponse{}
+	if err := convertCRIMessage(nilResp, resp); err != nil {
+		t.Fatal(err)
+	}
+}
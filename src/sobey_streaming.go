@@ -0,0 +1,374 @@
+package src
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"k8s.io/client-go/tools/remotecommand"
+	runtimeapiv1 "k8s.io/cri-api/pkg/apis/runtime/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/klog/v2"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/cri/streaming"
+	"k8s.io/kubernetes/pkg/kubelet/util/ioutils"
+	utilexec "k8s.io/utils/exec"
+	"math"
+	"net"
+	"os"
+	"os/exec"
+	"sobey-runtime/monitor"
+	util "sobey-runtime/utils"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// How long port forward waits for the second direction to finish after the first one did.
+	portForwardCloseTimeout = time.Second
+
+	// How long a tty exec keeps copying the output after the command exited,
+	// a process left in the background may hold the terminal open.
+	execOutputDrainTimeout = 2 * time.Second
+
+	// Limit of the stdout and stderr kept by ExecSync, the same as the grpc message size.
+	maxMsgSize = 1024 * 1024 * 16
+)
+
+// streamingRuntime implements the streaming.Runtime interface on top of
+// the socker processes recorded in etcd.
+type streamingRuntime struct {
+	ss *sobeyService
+}
+
+var _ streaming.Runtime = &streamingRuntime{}
+
+func (r *streamingRuntime) Exec(containerID string, cmd []string, in io.Reader, out, errw io.WriteCloser, tty bool, resize <-chan remotecommand.TerminalSize) error {
+	return r.exec(context.TODO(), containerID, cmd, in, out, errw, tty, resize, 0)
+}
+
+// Internal version of Exec adds a timeout.
+func (r *streamingRuntime) exec(ctx context.Context, containerID string, cmd []string, in io.Reader, out, errw io.WriteCloser, tty bool, resize <-chan remotecommand.TerminalSize, timeout time.Duration) error {
+	container, err := r.ss.checkContainerStatus(containerID)
+	if err != nil {
+		return err
+	}
+	return execInContainer(ctx, container, cmd, in, out, errw, tty, resize, timeout)
+}
+
+func (r *streamingRuntime) Attach(containerID string, in io.Reader, out, errw io.WriteCloser, tty bool, resize <-chan remotecommand.TerminalSize) error {
+	container, err := r.ss.checkContainerStatus(containerID)
+	if err != nil {
+		return err
+	}
+	return attachContainer(container, in, out, errw)
+}
+
+func (r *streamingRuntime) PortForward(podSandboxID string, port int32, stream io.ReadWriteCloser) error {
+	if port < 0 || port > math.MaxUint16 {
+		return fmt.Errorf("invalid port %d", port)
+	}
+	sandbox, err := r.ss.getSobeySandbox(podSandboxID)
+	if err != nil {
+		return err
+	}
+	netNSPath, err := r.ss.GetNetNS(sandbox.Pid)
+	if err != nil {
+		return err
+	}
+	return portForward(netNSPath, port, stream)
+}
+
+// ExecSync executes a command in the container, and returns the stdout output.
+// If command exits with a non-zero exit code, the exit code is returned with the output.
+func (ss *sobeyService) ExecSync(ctx context.Context, req *runtimeapi.ExecSyncRequest) (*runtimeapi.ExecSyncResponse, error) {
+	timeout := time.Duration(req.Timeout) * time.Second
+	var stdoutBuffer, stderrBuffer bytes.Buffer
+	err := ss.streamingRuntime.exec(ctx, req.ContainerId, req.Cmd,
+		nil, // in
+		ioutils.WriteCloserWrapper(ioutils.LimitWriter(&stdoutBuffer, maxMsgSize)),
+		ioutils.WriteCloserWrapper(ioutils.LimitWriter(&stderrBuffer, maxMsgSize)),
+		false, // tty
+		nil,   // resize
+		timeout)
+
+	// kubelet's remote runtime expects a grpc error with status code DeadlineExceeded on time out.
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, status.Errorf(codes.DeadlineExceeded, err.Error())
+	}
+
+	var exitCode int32
+	if err != nil {
+		exitError, ok := err.(utilexec.ExitError)
+		if !ok {
+			return nil, err
+		}
+		exitCode = int32(exitError.ExitStatus())
+	}
+	return &runtimeapi.ExecSyncResponse{
+		Stdout:   stdoutBuffer.Bytes(),
+		Stderr:   stderrBuffer.Bytes(),
+		ExitCode: exitCode,
+	}, nil
+}
+
+// Exec prepares a streaming endpoint to execute a command in the container, and returns the address.
+func (ss *sobeyService) Exec(ctx context.Context, req *runtimeapi.ExecRequest) (*runtimeapi.ExecResponse, error) {
+	if ss.streamingServer == nil {
+		return nil, streaming.NewErrorStreamingDisabled("exec")
+	}
+	_, err := ss.checkContainerStatus(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+	// The streaming server only speaks CRI v1.
+	v1Req := &runtimeapiv1.ExecRequest{}
+	if err = convertCRIMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := ss.streamingServer.GetExec(v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapi.ExecResponse{}
+	return resp, convertCRIMessage(v1Resp, resp)
+}
+
+// Attach prepares a streaming endpoint to attach to a running container, and returns the address.
+func (ss *sobeyService) Attach(ctx context.Context, req *runtimeapi.AttachRequest) (*runtimeapi.AttachResponse, error) {
+	if ss.streamingServer == nil {
+		return nil, streaming.NewErrorStreamingDisabled("attach")
+	}
+	_, err := ss.checkContainerStatus(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+	v1Req := &runtimeapiv1.AttachRequest{}
+	if err = convertCRIMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := ss.streamingServer.GetAttach(v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapi.AttachResponse{}
+	return resp, convertCRIMessage(v1Resp, resp)
+}
+
+// PortForward prepares a streaming endpoint to forward ports from a PodSandbox, and returns the address.
+func (ss *sobeyService) PortForward(ctx context.Context, req *runtimeapi.PortForwardRequest) (*runtimeapi.PortForwardResponse, error) {
+	if ss.streamingServer == nil {
+		return nil, streaming.NewErrorStreamingDisabled("port forward")
+	}
+	sandbox, err := ss.getSobeySandbox(req.PodSandboxId)
+	if err != nil {
+		return nil, err
+	}
+	if sandbox.State != runtimeapi.PodSandboxState_SANDBOX_READY {
+		return nil, fmt.Errorf("sandbox not ready (%s)", sandbox.ID)
+	}
+	v1Req := &runtimeapiv1.PortForwardRequest{}
+	if err = convertCRIMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := ss.streamingServer.GetPortForward(v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runtimeapi.PortForwardResponse{}
+	return resp, convertCRIMessage(v1Resp, resp)
+}
+
+// checkContainerStatus returns the record of a container which is running.
+func (ss *sobeyService) checkContainerStatus(containerID string) (*SobeyContainer, error) {
+	container, err := ss.getSobeyContainer(containerID)
+	if err != nil {
+		return nil, err
+	}
+	if container.State != runtimeapi.ContainerState_CONTAINER_RUNNING || len(containerPid(container)) == 0 {
+		return nil, fmt.Errorf("container not running (%s)", container.ID)
+	}
+	return container, nil
+}
+
+// containerPid returns the pid socker recorded for the container process.
+func containerPid(container *SobeyContainer) string {
+	return strings.TrimSpace(container.Pid)
+}
+
+// execInContainer runs cmd in the namespaces of the container process with nsenter.
+// A timeout of 0 means no timeout.
+func execInContainer(ctx context.Context, container *SobeyContainer, cmd []string, stdin io.Reader, stdout, stderr io.WriteCloser, tty bool, resize <-chan remotecommand.TerminalSize, timeout time.Duration) error {
+	nsenter, err := exec.LookPath("nsenter")
+	if err != nil {
+		return fmt.Errorf("exec unavailable - unable to locate nsenter")
+	}
+	args := []string{"-t", containerPid(container), "-m", "-i", "-u", "-n", "-p", "-r", "-w", "--", "env", "-i"}
+	for _, env := range container.ContainerConfig.GetEnvs() {
+		args = append(args, fmt.Sprintf("%s=%s", env.Key, env.Value))
+	}
+	args = append(args, cmd...)
+	command := exec.Command(nsenter, args...)
+
+	if tty {
+		master, slave, err := util.OpenPty()
+		if err != nil {
+			return err
+		}
+		defer master.Close()
+		command.Stdin = slave
+		command.Stdout = slave
+		command.Stderr = slave
+		command.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+		err = command.Start()
+		_ = slave.Close()
+		if err != nil {
+			return err
+		}
+
+		if stdout != nil {
+			// make sure to close the stdout stream
+			defer stdout.Close()
+		}
+
+		kubecontainer.HandleResizing(resize, func(size remotecommand.TerminalSize) {
+			if err := util.SetPtySize(master, size.Width, size.Height); err != nil {
+				klog.ErrorS(err, "Failed to resize exec terminal", "containerID", container.ID)
+			}
+		})
+		if stdin != nil {
+			go func() { _, _ = io.Copy(master, stdin) }()
+		}
+		var drained chan struct{}
+		if stdout != nil {
+			drained = make(chan struct{})
+			go func() {
+				defer close(drained)
+				_, _ = io.Copy(stdout, master)
+			}()
+		}
+		err = waitCommand(ctx, command, timeout)
+		// The terminal keeps the last output of the command until it is read.
+		if drained != nil {
+			select {
+			case <-drained:
+			case <-time.After(execOutputDrainTimeout):
+				klog.InfoS("Output of exec is still open after the command exited", "containerID", container.ID)
+			}
+		}
+		return err
+	}
+
+	if stdin != nil {
+		// Use an os.Pipe here as it returns true *os.File objects.
+		// This way, if you run 'kubectl exec <pod> -i bash' (no tty) and type 'exit',
+		// the call below to command.Run() can unblock because its Stdin is the read half
+		// of the pipe.
+		r, w, err := os.Pipe()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		go func() {
+			_, _ = io.Copy(w, stdin)
+			_ = w.Close()
+		}()
+		command.Stdin = r
+	}
+	if stdout != nil {
+		command.Stdout = stdout
+	}
+	if stderr != nil {
+		command.Stderr = stderr
+	}
+	// nsenter forks the command into the pid namespace of the container, so
+	// the whole process group has to be killed on timeout.
+	command.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err = command.Start(); err != nil {
+		return err
+	}
+	return waitCommand(ctx, command, timeout)
+}
+
+// waitCommand waits for a started command and kills its process group once
+// ctx is done or the timeout expires. A non-zero exit is returned as a
+// utilexec.CodeExitError, which the streaming server reports as the exit
+// code of the command.
+func waitCommand(ctx context.Context, command *exec.Cmd, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- command.Wait()
+	}()
+	select {
+	case err := <-done:
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return utilexec.CodeExitError{Err: err, Code: exitErr.ExitCode()}
+		}
+		return err
+	case <-ctx.Done():
+		_ = syscall.Kill(-command.Process.Pid, syscall.SIGKILL)
+		<-done
+		return fmt.Errorf("exec command timed out: %w", ctx.Err())
+	}
+}
+
+// attachContainer connects the streams to the monitor which owns the stdio
+// of the container.
+func attachContainer(container *SobeyContainer, stdin io.Reader, stdout, stderr io.WriteCloser) error {
+	if stdin != nil && !container.ContainerConfig.GetStdin() {
+		return fmt.Errorf("container %s is not created with stdin", container.ID)
+	}
+	return monitor.Attach(container.ID, stdin, stdout, stderr)
+}
+
+// portForward copies the stream to a TCP connection to localhost:port which
+// is opened inside the network namespace of the sandbox.
+func portForward(netNSPath string, port int32, stream io.ReadWriteCloser) error {
+	var conn net.Conn
+	err := util.WithNetNS(netNSPath, func() error {
+		var dialErr error
+		conn, dialErr = net.Dial("tcp4", fmt.Sprintf("localhost:%d", port))
+		return dialErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to localhost:%d inside namespace %q: %v", port, netNSPath, err)
+	}
+	defer conn.Close()
+
+	errCh := make(chan error, 2)
+	// Copy from the namespace port connection to the client stream
+	go func() {
+		klog.V(4).InfoS("PortForward copying data from namespace port to the client stream", "netns", netNSPath, "port", port)
+		_, err := io.Copy(stream, conn)
+		errCh <- err
+	}()
+
+	// Copy from the client stream to the namespace port connection
+	go func() {
+		klog.V(4).InfoS("PortForward copying data from client stream to namespace port", "netns", netNSPath, "port", port)
+		_, err := io.Copy(conn, stream)
+		errCh <- err
+	}()
+
+	// Wait until the first error is returned by one of the connections,
+	// then give the other direction a chance to terminate gracefully.
+	errFwd := <-errCh
+	select {
+	case e := <-errCh:
+		if errFwd == nil {
+			errFwd = e
+		}
+	case <-time.After(portForwardCloseTimeout):
+		klog.V(4).InfoS("PortForward timed out waiting to close the connection", "netns", netNSPath, "port", port)
+	}
+	return errFwd
+}
@@ -0,0 +1,101 @@
+package src
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim"
+	"sobey-runtime/common"
+	util "sobey-runtime/utils"
+)
+
+// Key of the JSON debug data in the Info map of a verbose status response.
+const verboseInfoKey = "info"
+
+// sandboxDebugInfo is the debug data of a sandbox. Data which can not be read is
+// left out, and the reason is reported in Errors.
+type sandboxDebugInfo struct {
+	Pid        string            `json:"pid"`
+	NetNSPath  string            `json:"netNamespacePath"`
+	CNIResult  json.RawMessage   `json:"cniResult,omitempty"`
+	Checkpoint json.RawMessage   `json:"checkpoint,omitempty"`
+	Errors     map[string]string `json:"errors,omitempty"`
+}
+
+// containerDebugInfo is the debug data of a container, see sandboxDebugInfo.
+type containerDebugInfo struct {
+	Pid          string            `json:"pid"`
+	PPid         string            `json:"ppid,omitempty"`
+	LogPath      string            `json:"logPath"`
+	RealLogPath  string            `json:"realLogPath"`
+	RootfsPath   string            `json:"rootfsPath"`
+	SockerConfig json.RawMessage   `json:"sockerConfig,omitempty"`
+	Record       json.RawMessage   `json:"record"`
+	Errors       map[string]string `json:"errors,omitempty"`
+}
+
+func (ss *sobeyService) sandboxVerboseInfo(sandbox *SobeySandbox) (map[string]string, error) {
+	info := &sandboxDebugInfo{
+		Pid:    sandbox.Pid,
+		Errors: make(map[string]string),
+	}
+	netNSPath, err := ss.GetNetNS(sandbox.Pid)
+	if err != nil {
+		info.Errors["netNamespacePath"] = err.Error()
+	}
+	info.NetNSPath = netNSPath
+	info.CNIResult, err = readRawJSON(cniResultPath(sandbox.ID))
+	if err != nil {
+		info.Errors["cniResult"] = err.Error()
+	}
+	checkpoint := dockershim.NewPodSandboxCheckpoint("", "", &dockershim.CheckpointData{})
+	err = ss.checkpointManager.GetCheckpoint(sandbox.ID, checkpoint)
+	if err != nil {
+		info.Errors["checkpoint"] = err.Error()
+	} else if info.Checkpoint, err = checkpoint.MarshalCheckpoint(); err != nil {
+		info.Errors["checkpoint"] = err.Error()
+	}
+	return marshalVerboseInfo(info)
+}
+
+func containerVerboseInfo(container *SobeyContainer, record string) (map[string]string, error) {
+	info := &containerDebugInfo{
+		Pid:         containerPid(container),
+		LogPath:     container.Labels[common.ContainerLogPathLabelKey],
+		RealLogPath: container.Path,
+		RootfsPath:  fmt.Sprintf(common.SockerContainerFSHome, container.ID) + "/mnt",
+		Record:      json.RawMessage(record),
+		Errors:      make(map[string]string),
+	}
+	var err error
+	if len(info.Pid) != 0 {
+		info.PPid, err = util.ParentPid(info.Pid)
+		if err != nil {
+			info.Errors["ppid"] = err.Error()
+		}
+	}
+	info.SockerConfig, err = readRawJSON(fmt.Sprintf("%s/config.json", fmt.Sprintf(common.SockerContainerConfHome, container.ID)))
+	if err != nil {
+		info.Errors["sockerConfig"] = err.Error()
+	}
+	return marshalVerboseInfo(info)
+}
+
+func readRawJSON(path string) (json.RawMessage, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(bytes) {
+		return nil, fmt.Errorf("%s is not valid json", path)
+	}
+	return bytes, nil
+}
+
+func marshalVerboseInfo(info interface{}) (map[string]string, error) {
+	bytes, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{verboseInfoKey: string(bytes)}, nil
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// DiskUsage returns the bytes allocated to the files under path and the
+// number of inodes they use. Hard linked files are counted once, symbolic
+// links are not followed, and the walk does not cross into the filesystems
+// mounted under path.
+func DiskUsage(path string) (uint64, uint64, error) {
+	type inode struct {
+		dev uint64
+		ino uint64
+	}
+	var root syscall.Stat_t
+	if err := syscall.Lstat(path, &root); err != nil {
+		return 0, 0, err
+	}
+	seen := make(map[inode]struct{})
+	var bytes, inodes uint64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Files of a running container come and go during the walk.
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return nil
+		}
+		if stat.Dev != root.Dev {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		key := inode{dev: uint64(stat.Dev), ino: stat.Ino}
+		if _, ok = seen[key]; ok {
+			return nil
+		}
+		seen[key] = struct{}{}
+		bytes += uint64(stat.Blocks) * 512
+		inodes++
+		return nil
+	})
+	return bytes, inodes, err
+}
+
+// FsUsage returns the capacity and the available bytes of the filesystem
+// holding path.
+func FsUsage(path string) (uint64, uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+	return stat.Blocks * uint64(stat.Bsize), stat.Bavail * uint64(stat.Bsize), nil
+}
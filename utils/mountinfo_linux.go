@@ -0,0 +1,126 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// MountInfo is one line of /proc/<pid>/mountinfo.
+type MountInfo struct {
+	// Major and minor number of the device, "major:minor".
+	Device string
+	// Path within the filesystem which is mounted.
+	Root       string
+	MountPoint string
+	// Per mount options, like "rw,nosuid".
+	Options []string
+	// Optional fields, like "shared:1" or "master:2".
+	Optional []string
+	FSType   string
+	Source   string
+	// Per superblock options, like "rw,upperdir=/a,lowerdir=/b" for overlay.
+	SuperOptions []string
+}
+
+// ReadOnly tells whether the mount is read only.
+func (m *MountInfo) ReadOnly() bool {
+	for _, option := range m.Options {
+		if option == "ro" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasOptional tells whether one of the optional fields has the prefix, like "shared:".
+func (m *MountInfo) HasOptional(prefix string) bool {
+	for _, optional := range m.Optional {
+		if strings.HasPrefix(optional, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SuperOption returns the value of the per superblock option, like the
+// "upperdir" of an overlay mount.
+func (m *MountInfo) SuperOption(name string) (string, bool) {
+	for _, option := range m.SuperOptions {
+		if option == name {
+			return "", true
+		}
+		if strings.HasPrefix(option, name+"=") {
+			return option[len(name)+1:], true
+		}
+	}
+	return "", false
+}
+
+// ReadMountInfo returns the mounts seen by the process with the pid, "self"
+// is the current process.
+func ReadMountInfo(pid string) ([]MountInfo, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%s/mountinfo", strings.TrimSpace(pid)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseMountInfo(f)
+}
+
+// ParseMountInfo parses the content of a mountinfo file.
+func ParseMountInfo(r io.Reader) ([]MountInfo, error) {
+	var mounts []MountInfo
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// mount-id parent-id major:minor root mount-point options [optional...] - fstype source super-options
+		fields := strings.Fields(scanner.Text())
+		separator := -1
+		for i := 6; i < len(fields); i++ {
+			if fields[i] == "-" {
+				separator = i
+				break
+			}
+		}
+		if separator < 0 || len(fields) < separator+3 {
+			continue
+		}
+		mount := MountInfo{
+			Device:     fields[2],
+			Root:       unescapeMountPath(fields[3]),
+			MountPoint: unescapeMountPath(fields[4]),
+			Options:    strings.Split(fields[5], ","),
+			Optional:   fields[6:separator],
+			FSType:     fields[separator+1],
+			Source:     fields[separator+2],
+		}
+		if len(fields) > separator+3 {
+			mount.SuperOptions = strings.Split(fields[separator+3], ",")
+		}
+		mounts = append(mounts, mount)
+	}
+	return mounts, scanner.Err()
+}
+
+// unescapeMountPath decodes the octal escapes the kernel uses for spaces,
+// tabs, newlines and backslashes in paths.
+func unescapeMountPath(path string) string {
+	if !strings.Contains(path, "\\") {
+		return path
+	}
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			var c byte
+			if _, err := fmt.Sscanf(path[i+1:i+4], "%03o", &c); err == nil {
+				b.WriteByte(c)
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMountInfo(t *testing.T) {
+	content := `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+480 470 8:1 /srv/app\040data /data ro,relatime master:1 - ext4 /dev/sda1 rw
+`
+	mounts, err := ParseMountInfo(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := MountInfo{
+		Device:       "8:1",
+		Root:         "/srv/app data",
+		MountPoint:   "/data",
+		Options:      []string{"ro", "relatime"},
+		Optional:     []string{"master:1"},
+		FSType:       "ext4",
+		Source:       "/dev/sda1",
+		SuperOptions: []string{"rw"},
+	}
+	if len(mounts) != 2 || !reflect.DeepEqual(mounts[1], expected) {
+		t.Fatalf("expected %+v, got %+v", expected, mounts)
+	}
+	if !mounts[1].ReadOnly() || mounts[0].ReadOnly() {
+		t.Fatalf("unexpected read only flags")
+	}
+	if !mounts[0].HasOptional("shared:") {
+		t.Fatalf("expected the root mount to be shared")
+	}
+
+	overlay, err := ParseMountInfo(strings.NewReader("600 22 0:52 / /run/c/fs/mnt rw - overlay overlay rw,lowerdir=/l,upperdir=/run/c/fs/upper,workdir=/run/c/fs/work\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upper, ok := overlay[0].SuperOption("upperdir"); !ok || upper != "/run/c/fs/upper" {
+		t.Fatalf("unexpected upperdir %q", upper)
+	}
+	if _, ok := overlay[0].SuperOption("index"); ok {
+		t.Fatalf("unexpected index option")
+	}
+}
@@ -35,6 +35,9 @@ func MakeContainerName(s *runtimeapi.PodSandboxConfig, c *runtimeapi.ContainerCo
 func ToPullableImageID(id string, pullable bool) string {
 	// Default to the image ID, but if RepoDigests is not empty, use
 	// the first digest instead.
+	if ref, err := ParseImageReference(id); err == nil {
+		id = ref.String()
+	}
 	imageID := SobeyImageIDPrefix + id
 	if pullable {
 		imageID = SobeyPullableImageIDPrefix + id
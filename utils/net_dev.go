@@ -0,0 +1,72 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// InterfaceStats are the counters of a network interface in /proc/<pid>/net/dev.
+type InterfaceStats struct {
+	Name      string
+	RxBytes   uint64
+	RxPackets uint64
+	RxErrors  uint64
+	RxDropped uint64
+	TxBytes   uint64
+	TxPackets uint64
+	TxErrors  uint64
+	TxDropped uint64
+}
+
+// ReadNetDev returns the counters of the interfaces in the network namespace
+// of the process with the pid.
+func ReadNetDev(pid string) ([]InterfaceStats, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%s/net/dev", strings.TrimSpace(pid)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseNetDev(f)
+}
+
+func parseNetDev(r io.Reader) ([]InterfaceStats, error) {
+	var interfaces []InterfaceStats
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// The first two lines are headers, the interface lines look like
+		// "  eth0: rx_bytes rx_packets rx_errs rx_drop ... tx_bytes tx_packets tx_errs tx_drop ...".
+		line := scanner.Text()
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			continue
+		}
+		fields := strings.Fields(line[idx+1:])
+		if len(fields) < 16 {
+			continue
+		}
+		var values [16]uint64
+		for i := range values {
+			value, err := strconv.ParseUint(fields[i], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid counter %q of interface %s", fields[i], strings.TrimSpace(line[:idx]))
+			}
+			values[i] = value
+		}
+		interfaces = append(interfaces, InterfaceStats{
+			Name:      strings.TrimSpace(line[:idx]),
+			RxBytes:   values[0],
+			RxPackets: values[1],
+			RxErrors:  values[2],
+			RxDropped: values[3],
+			TxBytes:   values[8],
+			TxPackets: values[9],
+			TxErrors:  values[10],
+			TxDropped: values[11],
+		})
+	}
+	return interfaces, scanner.Err()
+}
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNetDev(t *testing.T) {
+	content := `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:     120       2    0    0    0     0          0         0      120       2    0    0    0     0       0          0
+  eth0: 1245634    9800    1    2    0     0          0         0   873422    7012    3    4    0     0       0          0
+`
+	interfaces, err := parseNetDev(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(interfaces) != 2 {
+		t.Fatalf("expected 2 interfaces, got %d", len(interfaces))
+	}
+	expected := InterfaceStats{
+		Name:      "eth0",
+		RxBytes:   1245634,
+		RxPackets: 9800,
+		RxErrors:  1,
+		RxDropped: 2,
+		TxBytes:   873422,
+		TxPackets: 7012,
+		TxErrors:  3,
+		TxDropped: 4,
+	}
+	if interfaces[1] != expected {
+		t.Fatalf("expected %+v, got %+v", expected, interfaces[1])
+	}
+}
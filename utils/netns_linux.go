@@ -0,0 +1,45 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// WithNetNS runs fn on an OS thread switched into the network namespace at
+// nsPath. Sockets created by fn stay in that namespace after it returns.
+func WithNetNS(nsPath string, fn func() error) error {
+	errCh := make(chan error, 1)
+	go func() {
+		// The thread is only handed back to the scheduler once it is in its
+		// original namespace again, otherwise it exits with the goroutine.
+		runtime.LockOSThread()
+		origin, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", unix.Gettid()))
+		if err != nil {
+			runtime.UnlockOSThread()
+			errCh <- err
+			return
+		}
+		defer origin.Close()
+		target, err := os.Open(nsPath)
+		if err != nil {
+			runtime.UnlockOSThread()
+			errCh <- err
+			return
+		}
+		defer target.Close()
+		if err = unix.Setns(int(target.Fd()), unix.CLONE_NEWNET); err != nil {
+			runtime.UnlockOSThread()
+			errCh <- fmt.Errorf("failed to enter network namespace %q: %v", nsPath, err)
+			return
+		}
+		fnErr := fn()
+		if err = unix.Setns(int(origin.Fd()), unix.CLONE_NEWNET); err == nil {
+			runtime.UnlockOSThread()
+		}
+		errCh <- fnErr
+	}()
+	return <-errCh
+}
@@ -2,9 +2,11 @@ package util
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"strconv"
+	"strings"
 	"syscall"
 )
 
@@ -58,3 +60,22 @@ func openFile(path, fileName string) (*os.File, error) {
 	}
 	return file, err
 }
+
+// ParentPid returns the pid of the parent of the process with the pid.
+func ParentPid(pid string) (string, error) {
+	bytes, err := ioutil.ReadFile(fmt.Sprintf("/proc/%s/stat", strings.TrimSpace(pid)))
+	if err != nil {
+		return "", err
+	}
+	// "pid (comm) state ppid ...", comm may contain spaces and parentheses.
+	stat := string(bytes)
+	idx := strings.LastIndex(stat, ")")
+	if idx < 0 {
+		return "", fmt.Errorf("invalid stat of process %s", pid)
+	}
+	fields := strings.Fields(stat[idx+1:])
+	if len(fields) < 2 {
+		return "", fmt.Errorf("invalid stat of process %s", pid)
+	}
+	return fields[1], nil
+}
@@ -0,0 +1,130 @@
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultImageTag is the tag of an image reference without tag and digest.
+	DefaultImageTag = "latest"
+	// sha256DigestPrefix is the prefix of a content digest.
+	sha256DigestPrefix = "sha256:"
+	maxTagLength       = 128
+)
+
+// ImageReference is a parsed image reference, like
+// "nexus.example.com:8443/app/demo:1.0@sha256:<hex>".
+type ImageReference struct {
+	// Registry host with an optional port, empty when the reference has none.
+	Registry string
+	// Repository path, like "app/demo".
+	Path string
+	Tag  string
+	// Digest of the content, like "sha256:<hex>".
+	Digest string
+}
+
+// ParseImageReference parses and normalizes an image reference. The first
+// path component is the registry when it holds a "." or a ":", or is
+// "localhost".
+func ParseImageReference(ref string) (*ImageReference, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("image reference is empty")
+	}
+	if IsImageDigest(ref) {
+		return nil, fmt.Errorf("image reference %q is a digest without a name", ref)
+	}
+	result := new(ImageReference)
+	remaining := ref
+	if idx := strings.Index(remaining, "@"); idx >= 0 {
+		result.Digest = strings.ToLower(remaining[idx+1:])
+		remaining = remaining[:idx]
+		if !IsImageDigest(result.Digest) {
+			return nil, fmt.Errorf("invalid digest in image reference %q", ref)
+		}
+	}
+	// The tag follows the last ":" after the last "/", so a registry port is
+	// not taken for a tag.
+	if idx := strings.LastIndex(remaining, ":"); idx > strings.LastIndex(remaining, "/") {
+		result.Tag = remaining[idx+1:]
+		remaining = remaining[:idx]
+		if !validTag(result.Tag) {
+			return nil, fmt.Errorf("invalid tag in image reference %q", ref)
+		}
+	}
+	if idx := strings.Index(remaining, "/"); idx >= 0 {
+		first := remaining[:idx]
+		if (strings.ContainsAny(first, ".:") && first != "." && first != "..") || first == "localhost" {
+			result.Registry = strings.ToLower(first)
+			remaining = remaining[idx+1:]
+		}
+	}
+	result.Path = remaining
+	for _, component := range strings.Split(result.Path, "/") {
+		if component == "" || component == "." || component == ".." || strings.ContainsAny(component, " \t\n\\") {
+			return nil, fmt.Errorf("invalid path in image reference %q", ref)
+		}
+	}
+	if result.Tag == "" && result.Digest == "" {
+		result.Tag = DefaultImageTag
+	}
+	return result, nil
+}
+
+// Name returns the registry and the path of the reference.
+func (r *ImageReference) Name() string {
+	if r.Registry == "" {
+		return r.Path
+	}
+	return r.Registry + "/" + r.Path
+}
+
+// StoreName returns the name the image is stored under in the image store,
+// the name followed by the tag, which is left out when it is "latest".
+func (r *ImageReference) StoreName() string {
+	if r.Tag == "" || r.Tag == DefaultImageTag {
+		return r.Name()
+	}
+	return r.Name() + ":" + r.Tag
+}
+
+// String returns the normalized reference.
+func (r *ImageReference) String() string {
+	s := r.Name()
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// IsImageDigest tells whether s is a content digest like "sha256:<hex>",
+// which is also the ID of an image.
+func IsImageDigest(s string) bool {
+	if !strings.HasPrefix(s, sha256DigestPrefix) {
+		return false
+	}
+	sum := strings.TrimPrefix(s, sha256DigestPrefix)
+	if len(sum) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(sum)
+	return err == nil
+}
+
+func validTag(tag string) bool {
+	if tag == "" || len(tag) > maxTagLength || tag[0] == '.' || tag[0] == '-' {
+		return false
+	}
+	for _, c := range tag {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseImageReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	for _, c := range []struct {
+		ref       string
+		expected  ImageReference
+		storeName string
+	}{
+		{"demo", ImageReference{Path: "demo", Tag: "latest"}, "demo"},
+		{"app/demo:latest", ImageReference{Path: "app/demo", Tag: "latest"}, "app/demo"},
+		{"app/demo:1.0", ImageReference{Path: "app/demo", Tag: "1.0"}, "app/demo:1.0"},
+		{"Nexus.example.com:8443/app/sub/demo", ImageReference{Registry: "nexus.example.com:8443", Path: "app/sub/demo", Tag: "latest"}, "nexus.example.com:8443/app/sub/demo"},
+		{"localhost:5000/demo:2", ImageReference{Registry: "localhost:5000", Path: "demo", Tag: "2"}, "localhost:5000/demo:2"},
+		{"localhost/demo", ImageReference{Registry: "localhost", Path: "demo", Tag: "latest"}, "localhost/demo"},
+		{"app/demo@" + digest, ImageReference{Path: "app/demo", Digest: digest}, "app/demo"},
+		{"app/demo:1.0@" + strings.ToUpper(digest[7:]), ImageReference{}, ""},
+		{"app/demo:1.0@" + digest, ImageReference{Path: "app/demo", Tag: "1.0", Digest: digest}, "app/demo:1.0"},
+	} {
+		ref, err := ParseImageReference(c.ref)
+		if c.storeName == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %+v", c.ref, ref)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.ref, err)
+			continue
+		}
+		if *ref != c.expected || ref.StoreName() != c.storeName {
+			t.Errorf("%s: unexpected reference %+v, store name %s", c.ref, ref, ref.StoreName())
+		}
+	}
+	for _, ref := range []string{"", digest, "app//demo", "../demo", "app/demo:", "app/demo:-1", "app/demo@sha256:12"} {
+		if _, err := ParseImageReference(ref); err == nil {
+			t.Errorf("%q: expected an error", ref)
+		}
+	}
+}